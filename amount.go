@@ -0,0 +1,199 @@
+package xfers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidAmount is error when parsing invalid amount.
+var ErrInvalidAmount = errors.New("invalid amount")
+
+// amountScale is the number of minor unit in 1 rupiah.
+// Xfers uses 2 decimal places for amount.
+const amountScale = 100
+
+// Amount is exact money amount in IDR.
+//
+// The amount is stored as integer minor unit (1/100 rupiah)
+// so there is no rounding error like float. Zero value is 0.
+//
+// Arithmetic methods and NewAmount don't check overflow, same as
+// int64. The range is about ±92 quadrillion rupiah. ParseAmount and
+// json decoding return ErrInvalidAmount if the amount is out of range.
+type Amount struct {
+	minor int64
+}
+
+// NewAmount to create amount from whole rupiah.
+func NewAmount(rupiah int64) Amount {
+	return Amount{minor: rupiah * amountScale}
+}
+
+// NewAmountFromMinor to create amount from minor unit (1/100 rupiah).
+func NewAmountFromMinor(minor int64) Amount {
+	return Amount{minor: minor}
+}
+
+// ParseAmount to parse decimal string amount like "10000", "10000.5"
+// or "-10000.50". More than 2 non-zero decimal places is invalid.
+func ParseAmount(str string) (Amount, error) {
+	s := strings.TrimSpace(str)
+
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+
+	whole, frac, _ := strings.Cut(s, ".")
+	if whole == "" || !isDigit(whole) || !isDigit(frac) {
+		return Amount{}, fmt.Errorf("%w: %q", ErrInvalidAmount, str)
+	}
+
+	frac = strings.TrimRight(frac, "0")
+	if len(frac) > 2 {
+		return Amount{}, fmt.Errorf("%w: %q has more than 2 decimal places", ErrInvalidAmount, str)
+	}
+
+	f, _ := strconv.ParseInt((frac + "00")[:2], 10, 64)
+
+	w, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil || w > (math.MaxInt64-f)/amountScale {
+		return Amount{}, fmt.Errorf("%w: %q out of range", ErrInvalidAmount, str)
+	}
+
+	minor := w*amountScale + f
+	if neg {
+		minor = -minor
+	}
+
+	return Amount{minor: minor}, nil
+}
+
+func isDigit(str string) bool {
+	for _, c := range str {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Minor to get amount in minor unit (1/100 rupiah).
+func (a Amount) Minor() int64 {
+	return a.minor
+}
+
+// Float64 to get amount as float. May lose precision,
+// use it for display or metrics only.
+func (a Amount) Float64() float64 {
+	return float64(a.minor) / amountScale
+}
+
+// String to get amount as decimal string with 2 decimal places.
+func (a Amount) String() string {
+	minor := a.minor
+	sign := ""
+	if minor < 0 {
+		sign = "-"
+		minor = -minor
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, minor/amountScale, minor%amountScale)
+}
+
+// Add to get a + b.
+func (a Amount) Add(b Amount) Amount {
+	return Amount{minor: a.minor + b.minor}
+}
+
+// Sub to get a - b.
+func (a Amount) Sub(b Amount) Amount {
+	return Amount{minor: a.minor - b.minor}
+}
+
+// Mul to get a * n.
+func (a Amount) Mul(n int64) Amount {
+	return Amount{minor: a.minor * n}
+}
+
+// Neg to get -a.
+func (a Amount) Neg() Amount {
+	return Amount{minor: -a.minor}
+}
+
+// Cmp to compare a and b. Returns -1 if a < b, 0 if a == b,
+// and 1 if a > b.
+func (a Amount) Cmp(b Amount) int {
+	switch {
+	case a.minor < b.minor:
+		return -1
+	case a.minor > b.minor:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// IsZero to check if amount is 0.
+func (a Amount) IsZero() bool {
+	return a.minor == 0
+}
+
+// IsPositive to check if amount is greater than 0.
+func (a Amount) IsPositive() bool {
+	return a.minor > 0
+}
+
+// IsNegative to check if amount is less than 0.
+func (a Amount) IsNegative() bool {
+	return a.minor < 0
+}
+
+// MarshalJSON to encode amount as json number with 2 decimal places.
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalJSON to decode amount from json number or string.
+// Empty string and null are decoded as 0.
+func (a *Amount) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		*a = Amount{}
+		return nil
+	}
+
+	str := string(b)
+	if strings.HasPrefix(str, `"`) {
+		if err := json.Unmarshal(b, &str); err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidAmount, b)
+		}
+		if str == "" {
+			*a = Amount{}
+			return nil
+		}
+	}
+
+	v, err := ParseAmount(str)
+	if err != nil {
+		return err
+	}
+
+	*a = v
+	return nil
+}
+
+// MarshalText to encode amount as decimal string.
+func (a Amount) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText to decode amount from decimal string.
+func (a *Amount) UnmarshalText(b []byte) error {
+	v, err := ParseAmount(string(b))
+	if err != nil {
+		return err
+	}
+	*a = v
+	return nil
+}
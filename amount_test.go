@@ -0,0 +1,94 @@
+package xfers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/rl404/xfers-go"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		str     string
+		minor   int64
+		wantErr bool
+	}{
+		{str: "10000", minor: 1000000},
+		{str: "10000.5", minor: 1000050},
+		{str: "10000.50", minor: 1000050},
+		{str: "10000.500", minor: 1000050},
+		{str: " -10000.05 ", minor: -1000005},
+		{str: "0.01", minor: 1},
+		{str: "92233720368547758.07", minor: 9223372036854775807},
+		{str: "-92233720368547758.07", minor: -9223372036854775807},
+		{str: "92233720368547758.08", wantErr: true},
+		{str: "92233720368547758.99", wantErr: true},
+		{str: "92233720368547759", wantErr: true},
+		{str: "99999999999999999999", wantErr: true},
+		{str: "10000.001", wantErr: true},
+		{str: "", wantErr: true},
+		{str: ".5", wantErr: true},
+		{str: "1e5", wantErr: true},
+		{str: "+100", wantErr: true},
+		{str: "10.0.0", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		a, err := xfers.ParseAmount(tt.str)
+		if tt.wantErr {
+			if !errors.Is(err, xfers.ErrInvalidAmount) {
+				t.Errorf("%q: got %s %v, want %v", tt.str, a, err, xfers.ErrInvalidAmount)
+			}
+			continue
+		}
+		if err != nil || a.Minor() != tt.minor {
+			t.Errorf("%q: got %d %v, want %d", tt.str, a.Minor(), err, tt.minor)
+		}
+	}
+}
+
+func TestAmountJSON(t *testing.T) {
+	tests := []struct {
+		json    string
+		minor   int64
+		encoded string
+		wantErr bool
+	}{
+		{json: `10000`, minor: 1000000, encoded: `10000.00`},
+		{json: `10000.5`, minor: 1000050, encoded: `10000.50`},
+		{json: `"10000.05"`, minor: 1000005, encoded: `10000.05`},
+		{json: `-0.5`, minor: -50, encoded: `-0.50`},
+		{json: `""`, minor: 0, encoded: `0.00`},
+		{json: `null`, minor: 0, encoded: `0.00`},
+		{json: `0.1`, minor: 10, encoded: `0.10`},
+		{json: `0.123`, wantErr: true},
+		{json: `"abc"`, wantErr: true},
+		{json: `92233720368547758.99`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		var a xfers.Amount
+		err := json.Unmarshal([]byte(tt.json), &a)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s: got %s, want error", tt.json, a)
+			}
+			continue
+		}
+		if err != nil || a.Minor() != tt.minor {
+			t.Errorf("%s: got %d %v, want %d", tt.json, a.Minor(), err, tt.minor)
+			continue
+		}
+
+		b, err := json.Marshal(a)
+		if err != nil || string(b) != tt.encoded {
+			t.Errorf("%s: got %s %v, want %s", tt.json, b, err, tt.encoded)
+		}
+
+		var decoded xfers.Amount
+		if err := json.Unmarshal(b, &decoded); err != nil || decoded != a {
+			t.Errorf("%s: round trip got %s %v", tt.json, decoded, err)
+		}
+	}
+}
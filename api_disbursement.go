@@ -9,20 +9,23 @@ import (
 
 // Disbursement is disbursement model.
 type Disbursement struct {
-	ID                          string
-	ReferenceID                 string
-	Type                        DisbursementType
-	Amount                      float64
-	Fees                        float64
-	Status                      Status
-	BankAccountNo               string
-	BankShortCode               BankCode
-	BankName                    string
-	BankAccountHolderName       string
-	ServerBankAccountHolderName string
-	Description                 string
-	FailureReason               string
-	CreatedAt                   time.Time
+	ID                          string           `json:"id"`
+	ReferenceID                 string           `json:"referenceId"`
+	Type                        DisbursementType `json:"type"`
+	Amount                      Amount           `json:"amount"`
+	Fees                        Amount           `json:"fees"`
+	Status                      Status           `json:"status"`
+	BankAccountNo               string           `json:"bankAccountNo"`
+	BankShortCode               BankCode         `json:"bankShortCode"`
+	BankName                    string           `json:"bankName"`
+	BankAccountHolderName       string           `json:"bankAccountHolderName"`
+	ServerBankAccountHolderName string           `json:"serverBankAccountHolderName"`
+	Description                 string           `json:"description"`
+	FailureReason               string           `json:"failureReason"`
+	CreatedAt                   time.Time        `json:"createdAt"`
+	// Bank account verification result. Only set on create if
+	// Option.AccountVerification is enabled.
+	Verification *AccountVerification `json:"verification,omitempty"`
 }
 
 // CreateDisbursement to create new disbursement.
@@ -32,26 +35,83 @@ func (c *Client) CreateDisbursement(request CreateDisbursementRequest) (*Disburs
 
 // CreateDisbursementWithContext to create new disbursement with context.
 func (c *Client) CreateDisbursementWithContext(ctx context.Context, request CreateDisbursementRequest) (*Disbursement, int, error) {
+	ctx, span := c.startSpan(ctx, OpCreateDisbursement,
+		attrReferenceID.String(request.ReferenceID),
+		attrBankCode.String(string(request.BankShortCode)),
+	)
+	defer span.End()
+
 	if err := validate(&request); err != nil {
 		return nil, http.StatusBadRequest, err
 	}
 
-	var response disbursement
-	code, err := c.requester.Call(
-		ctx,
-		http.MethodPost,
-		fmt.Sprintf("%s/disbursements", c.baseURL),
-		c.apiKey,
-		c.secretKey,
-		nil,
-		request.wrap(),
-		&response,
-	)
+	ctx = withReferenceID(ctx, request.ReferenceID)
+
+	releaseSpending, code, err := c.reserveSpending(ctx, request)
+	if err != nil {
+		return nil, code, err
+	}
+
+	verification, code, err := c.verifyDisbursement(ctx, request)
+	if err != nil {
+		releaseSpending(false)
+		return nil, code, err
+	}
+
+	releaseBalance, code, err := c.reserveBalance(ctx, request.Amount)
 	if err != nil {
+		releaseSpending(false)
 		return nil, code, err
 	}
 
-	return response.toDisbursement(), code, nil
+	create := func() (*Disbursement, int, error) {
+		var response document[resource[disbursementAttributes]]
+		code, err := c.requester.Call(
+			ctx,
+			http.MethodPost,
+			fmt.Sprintf("%s/disbursements", c.baseURL),
+			c.apiKey,
+			c.secretKey,
+			idempotencyHeader(request.IdempotencyKey),
+			newDocument(attributesOf(request)),
+			&response,
+		)
+		if err != nil {
+			return nil, code, err
+		}
+		setSpanAttributes(ctx, attrResourceID.String(response.Data.ID), attrStatus.String(response.Data.Attributes.Status))
+		res := toDisbursement(response.Data)
+		return &res, code, nil
+	}
+
+	lookup := func() (*Disbursement, error) {
+		return c.lookupDisbursement(ctx, request.ReferenceID)
+	}
+
+	match := func(d *Disbursement) error {
+		return matchDisbursement(request, d)
+	}
+
+	res, code, existed, err := createIdempotent(ctx, c.idempotency, create, lookup, match)
+
+	// Disbursement created by an earlier call is already counted.
+	result := err
+	if existed {
+		result = errExisting
+	} else {
+		c.observeDisbursementCreated(ctx, request, err)
+	}
+
+	releaseBalance(result)
+	// Duplicate reference means the disbursement may exist already,
+	// so the reservation is kept.
+	releaseSpending(result == nil || isAmbiguous(result) || IsDuplicateReference(result))
+
+	if res != nil {
+		res.Verification = verification
+	}
+
+	return res, code, err
 }
 
 // GetDisbursement to get disbursement.
@@ -61,11 +121,14 @@ func (c *Client) GetDisbursement(id string) (*Disbursement, int, error) {
 
 // GetDisbursementWithContext to get disbursement with context.
 func (c *Client) GetDisbursementWithContext(ctx context.Context, id string) (*Disbursement, int, error) {
+	ctx, span := c.startSpan(ctx, OpGetDisbursement, attrResourceID.String(id))
+	defer span.End()
+
 	if id == "" {
 		return nil, http.StatusBadRequest, errRequiredField("id")
 	}
 
-	var response disbursement
+	var response document[resource[disbursementAttributes]]
 	code, err := c.requester.Call(
 		ctx,
 		http.MethodGet,
@@ -79,7 +142,8 @@ func (c *Client) GetDisbursementWithContext(ctx context.Context, id string) (*Di
 	if err != nil {
 		return nil, code, err
 	}
-	return response.toDisbursement(), code, nil
+	res := toDisbursement(response.Data)
+	return &res, code, nil
 }
 
 // GetDisbursements to get disbursement list.
@@ -89,11 +153,19 @@ func (c *Client) GetDisbursements(request Pagination) ([]Disbursement, int, erro
 
 // GetDisbursementsWithContext to get disbursement list with context.
 func (c *Client) GetDisbursementsWithContext(ctx context.Context, request Pagination) ([]Disbursement, int, error) {
+	disbursements, _, code, err := c.getDisbursements(ctx, request)
+	return disbursements, code, err
+}
+
+func (c *Client) getDisbursements(ctx context.Context, request Pagination) ([]Disbursement, PageInfo, int, error) {
+	ctx, span := c.startSpan(ctx, OpGetDisbursements, attrReferenceID.String(request.ReferenceID), attrStatus.String(string(request.Status)))
+	defer span.End()
+
 	if err := validate(&request); err != nil {
-		return nil, http.StatusBadRequest, err
+		return nil, PageInfo{}, http.StatusBadRequest, err
 	}
 
-	var response disbursements
+	var response document[[]resource[disbursementAttributes]]
 	code, err := c.requester.Call(
 		ctx,
 		http.MethodGet,
@@ -105,17 +177,17 @@ func (c *Client) GetDisbursementsWithContext(ctx context.Context, request Pagina
 		&response,
 	)
 	if err != nil {
-		return nil, code, err
+		return nil, PageInfo{}, code, err
 	}
 
-	return response.toDisbursements(), code, nil
+	return mapResources(response.Data, toDisbursement), response.pageInfo(request, len(response.Data)), code, nil
 }
 
 // DisbursementAction is response model from simulate disbursement.
 type DisbursementAction struct {
-	TargetID   string
-	TargetType string
-	Action     Action
+	TargetID   string `json:"targetId"`
+	TargetType string `json:"targetType"`
+	Action     Action `json:"action"`
 }
 
 // SimulateDisbursement to simulate disbursement status. Sandbox only.
@@ -125,6 +197,9 @@ func (c *Client) SimulateDisbursement(request SimulateDisbursementRequest) (*Dis
 
 // SimulateDisbursementWithContext to simulate disbursement status with context. Sandbox only.
 func (c *Client) SimulateDisbursementWithContext(ctx context.Context, request SimulateDisbursementRequest) (*DisbursementAction, int, error) {
+	ctx, span := c.startSpan(ctx, OpSimulateDisbursement, attrResourceID.String(request.ID))
+	defer span.End()
+
 	if c.env == Production {
 		return nil, http.StatusBadRequest, ErrSandboxOnly
 	}
@@ -133,7 +208,7 @@ func (c *Client) SimulateDisbursementWithContext(ctx context.Context, request Si
 		return nil, http.StatusBadRequest, err
 	}
 
-	var response disbursementAction
+	var response document[resource[actionAttributes]]
 	code, err := c.requester.Call(
 		ctx,
 		http.MethodPost,
@@ -141,12 +216,13 @@ func (c *Client) SimulateDisbursementWithContext(ctx context.Context, request Si
 		c.apiKey,
 		c.secretKey,
 		nil,
-		request.wrap(),
+		newDocument(attributesOf(request)),
 		&response,
 	)
 	if err != nil {
 		return nil, code, err
 	}
 
-	return response.toDisbursementAction(), code, nil
+	res := toDisbursementAction(response.Data)
+	return &res, code, nil
 }
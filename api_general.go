@@ -8,9 +8,9 @@ import (
 
 // Balance is account balance model.
 type Balance struct {
-	TotalBalance     float64
-	AvailableBalance float64
-	PendingBalance   float64
+	TotalBalance     Amount `json:"totalBalance"`
+	AvailableBalance Amount `json:"availableBalance"`
+	PendingBalance   Amount `json:"pendingBalance"`
 }
 
 // GetBalance to get account balance.
@@ -20,7 +20,10 @@ func (c *Client) GetBalance() (*Balance, int, error) {
 
 // GetBalanceWithContext to get account balance with context.
 func (c *Client) GetBalanceWithContext(ctx context.Context) (*Balance, int, error) {
-	var response balance
+	ctx, span := c.startSpan(ctx, OpGetBalance)
+	defer span.End()
+
+	var response document[resource[balanceAttributes]]
 	code, err := c.requester.Call(
 		ctx,
 		http.MethodGet,
@@ -34,13 +37,14 @@ func (c *Client) GetBalanceWithContext(ctx context.Context) (*Balance, int, erro
 	if err != nil {
 		return nil, code, err
 	}
-	return response.toBalance(), code, nil
+	res := toBalance(response.Data)
+	return &res, code, nil
 }
 
 // Bank is disbursement bank model.
 type Bank struct {
-	Name      string
-	ShortCode BankCode
+	Name      string   `json:"name"`
+	ShortCode BankCode `json:"shortCode"`
 }
 
 // GetBanks to get disbursement bank list.
@@ -50,7 +54,10 @@ func (c *Client) GetBanks() ([]Bank, int, error) {
 
 // GetBanksWithContext to get disbursement bank list with context.
 func (c *Client) GetBanksWithContext(ctx context.Context) ([]Bank, int, error) {
-	var response bank
+	ctx, span := c.startSpan(ctx, OpGetBanks)
+	defer span.End()
+
+	var response document[[]resource[bankAttributes]]
 	code, err := c.requester.Call(
 		ctx,
 		http.MethodGet,
@@ -64,14 +71,14 @@ func (c *Client) GetBanksWithContext(ctx context.Context) ([]Bank, int, error) {
 	if err != nil {
 		return nil, code, err
 	}
-	return response.toBanks(), code, nil
+	return mapResources(response.Data, toBank), code, nil
 }
 
 // BankAccount is bank account model.
 type BankAccount struct {
-	AccountName   string
-	AccountNo     string
-	BankShortCode BankCode
+	AccountName   string   `json:"accountName"`
+	AccountNo     string   `json:"accountNo"`
+	BankShortCode BankCode `json:"bankShortCode"`
 }
 
 // ValidateBankAccount to validate bank account.
@@ -81,24 +88,28 @@ func (c *Client) ValidateBankAccount(request ValidateBankAccountRequest) (*BankA
 
 // ValidateBankAccountWithContext to validate bank account with context.
 func (c *Client) ValidateBankAccountWithContext(ctx context.Context, request ValidateBankAccountRequest) (*BankAccount, int, error) {
+	ctx, span := c.startSpan(ctx, OpValidateBankAccount, attrBankCode.String(string(request.BankShortCode)))
+	defer span.End()
+
 	if err := validate(&request); err != nil {
 		return nil, http.StatusBadRequest, err
 	}
 
-	var response bankAccount
+	var response document[resource[bankAccountAttributes]]
 	code, err := c.requester.Call(
-		ctx,
+		withIdempotent(ctx),
 		http.MethodPost,
 		fmt.Sprintf("%s/validation_services/bank_account_validation", c.baseURL),
 		c.apiKey,
 		c.secretKey,
 		nil,
-		request.wrap(),
+		newDocument(attributesOf(request)),
 		&response,
 	)
 	if err != nil {
 		return nil, code, err
 	}
 
-	return response.toBankAccount(), code, nil
+	res := toBankAccount(response.Data)
+	return &res, code, nil
 }
@@ -9,24 +9,24 @@ import (
 
 // Payment is payment model.
 type Payment struct {
-	ID                 string
-	ReferenceID        string
-	PaymentMethodID    string
-	Type               PaymentType
-	Amount             float64
-	Fees               float64
-	Status             Status
-	Description        string
-	DisplayName        string
-	RetailOutletCode   RetailOutlet // retail
-	PaymentCode        string       // retail
-	BankShortCode      BankCode     // va
-	AccountNo          string       // va
-	ImageURL           string       // qris
-	HttpURL            string       // e-wallet
-	AfterSettlementURl string       // e-wallet
-	ExpiredAt          time.Time
-	CreatedAt          time.Time
+	ID                 string       `json:"id"`
+	ReferenceID        string       `json:"referenceId"`
+	PaymentMethodID    string       `json:"paymentMethodId"`
+	Type               PaymentType  `json:"type"`
+	Amount             Amount       `json:"amount"`
+	Fees               Amount       `json:"fees"`
+	Status             Status       `json:"status"`
+	Description        string       `json:"description"`
+	DisplayName        string       `json:"displayName"`
+	RetailOutletCode   RetailOutlet `json:"retailOutletCode,omitempty"`   // retail
+	PaymentCode        string       `json:"paymentCode,omitempty"`        // retail
+	BankShortCode      BankCode     `json:"bankShortCode,omitempty"`      // va
+	AccountNo          string       `json:"accountNo,omitempty"`          // va
+	ImageURL           string       `json:"imageUrl,omitempty"`           // qris
+	HttpURL            string       `json:"httpUrl,omitempty"`            // e-wallet
+	AfterSettlementURl string       `json:"afterSettlementUrl,omitempty"` // e-wallet
+	ExpiredAt          time.Time    `json:"expiredAt"`
+	CreatedAt          time.Time    `json:"createdAt"`
 }
 
 // CreatePayment to create new payment.
@@ -36,26 +36,55 @@ func (c *Client) CreatePayment(request CreatePaymentRequest) (*Payment, int, err
 
 // CreatePaymentWithContext to create new payment with context.
 func (c *Client) CreatePaymentWithContext(ctx context.Context, request CreatePaymentRequest) (*Payment, int, error) {
+	ctx, span := c.startSpan(ctx, OpCreatePayment,
+		attrReferenceID.String(request.ReferenceID),
+		attrPaymentType.String(string(request.PaymentMethodType)),
+		attrBankCode.String(string(request.BankShortCode)),
+	)
+	defer span.End()
+
 	if err := request.validate(); err != nil {
 		return nil, http.StatusBadRequest, err
 	}
 
-	var response payment
-	code, err := c.requester.Call(
-		ctx,
-		http.MethodPost,
-		fmt.Sprintf("%s/payments", c.baseURL),
-		c.apiKey,
-		c.secretKey,
-		nil,
-		request.wrap(),
-		&response,
-	)
-	if err != nil {
-		return nil, code, err
+	ctx = withReferenceID(ctx, request.ReferenceID)
+
+	create := func() (*Payment, int, error) {
+		var response document[resource[paymentAttributes]]
+		code, err := c.requester.Call(
+			ctx,
+			http.MethodPost,
+			fmt.Sprintf("%s/payments", c.baseURL),
+			c.apiKey,
+			c.secretKey,
+			idempotencyHeader(request.IdempotencyKey),
+			newDocument(attributesOf(request)),
+			&response,
+		)
+		if err != nil {
+			return nil, code, err
+		}
+		setSpanAttributes(ctx, attrResourceID.String(response.Data.ID), attrStatus.String(response.Data.Attributes.Status))
+		res := toPayment(response.Data)
+		return &res, code, nil
+	}
+
+	lookup := func() (*Payment, error) {
+		return c.lookupPayment(ctx, request.ReferenceID)
+	}
+
+	match := func(p *Payment) error {
+		return matchPayment(request, p)
 	}
 
-	return response.toPayment(), code, nil
+	res, code, existed, err := createIdempotent(ctx, c.idempotency, create, lookup, match)
+
+	// Payment created by an earlier call is already counted.
+	if !existed {
+		c.observePaymentCreated(ctx, request, err)
+	}
+
+	return res, code, err
 }
 
 // GetPayment to get payment.
@@ -65,11 +94,14 @@ func (c *Client) GetPayment(id string) (*Payment, int, error) {
 
 // GetPaymentWithContext to get payment with context.
 func (c *Client) GetPaymentWithContext(ctx context.Context, id string) (*Payment, int, error) {
+	ctx, span := c.startSpan(ctx, OpGetPayment, attrResourceID.String(id))
+	defer span.End()
+
 	if id == "" {
 		return nil, http.StatusBadRequest, errRequiredField("id")
 	}
 
-	var response payment
+	var response document[resource[paymentAttributes]]
 	code, err := c.requester.Call(
 		ctx,
 		http.MethodGet,
@@ -84,7 +116,8 @@ func (c *Client) GetPaymentWithContext(ctx context.Context, id string) (*Payment
 		return nil, code, err
 	}
 
-	return response.toPayment(), code, nil
+	res := toPayment(response.Data)
+	return &res, code, nil
 }
 
 // GetPayments to get payment list.
@@ -94,11 +127,19 @@ func (c *Client) GetPayments(request Pagination) ([]Payment, int, error) {
 
 // GetPaymentsWithContext to get disbursement list with context.
 func (c *Client) GetPaymentsWithContext(ctx context.Context, request Pagination) ([]Payment, int, error) {
+	payments, _, code, err := c.getPayments(ctx, request)
+	return payments, code, err
+}
+
+func (c *Client) getPayments(ctx context.Context, request Pagination) ([]Payment, PageInfo, int, error) {
+	ctx, span := c.startSpan(ctx, OpGetPayments, attrReferenceID.String(request.ReferenceID), attrStatus.String(string(request.Status)))
+	defer span.End()
+
 	if err := validate(&request); err != nil {
-		return nil, http.StatusBadRequest, err
+		return nil, PageInfo{}, http.StatusBadRequest, err
 	}
 
-	var response payments
+	var response document[[]resource[paymentAttributes]]
 	code, err := c.requester.Call(
 		ctx,
 		http.MethodGet,
@@ -110,17 +151,17 @@ func (c *Client) GetPaymentsWithContext(ctx context.Context, request Pagination)
 		&response,
 	)
 	if err != nil {
-		return nil, code, err
+		return nil, PageInfo{}, code, err
 	}
 
-	return response.toPayments(), code, nil
+	return mapResources(response.Data, toPayment), response.pageInfo(request, len(response.Data)), code, nil
 }
 
 // PaymentAction is response model from simulate payment.
 type PaymentAction struct {
-	TargetID   string
-	TargetType string
-	Action     Action
+	TargetID   string `json:"targetId"`
+	TargetType string `json:"targetType"`
+	Action     Action `json:"action"`
 }
 
 // SimulatePayment to simulate payment status. Sandbox only.
@@ -130,6 +171,9 @@ func (c *Client) SimulatePayment(request SimulatePaymentRequest) (*PaymentAction
 
 // SimulatePaymentWithContext to simulate payment status with context. Sandbox only.
 func (c *Client) SimulatePaymentWithContext(ctx context.Context, request SimulatePaymentRequest) (*PaymentAction, int, error) {
+	ctx, span := c.startSpan(ctx, OpSimulatePayment, attrResourceID.String(request.ID))
+	defer span.End()
+
 	if c.env == Production {
 		return nil, http.StatusBadRequest, ErrSandboxOnly
 	}
@@ -138,7 +182,7 @@ func (c *Client) SimulatePaymentWithContext(ctx context.Context, request Simulat
 		return nil, http.StatusBadRequest, err
 	}
 
-	var response paymentAction
+	var response document[resource[actionAttributes]]
 	code, err := c.requester.Call(
 		ctx,
 		http.MethodPost,
@@ -146,12 +190,13 @@ func (c *Client) SimulatePaymentWithContext(ctx context.Context, request Simulat
 		c.apiKey,
 		c.secretKey,
 		nil,
-		request.wrap(),
+		newDocument(attributesOf(request)),
 		&response,
 	)
 	if err != nil {
 		return nil, code, err
 	}
 
-	return response.toPaymentAction(), code, nil
+	res := toPaymentAction(response.Data)
+	return &res, code, nil
 }
@@ -8,13 +8,13 @@ import (
 
 // PaymentMethod is payment method model.
 type PaymentMethod struct {
-	ID            string
-	Type          PaymentType
-	ReferenceID   string
-	DisplayName   string
-	BankShortCode BankCode // va
-	AccountNo     string   // va
-	ImageURL      string   // qris
+	ID            string      `json:"id"`
+	Type          PaymentType `json:"type"`
+	ReferenceID   string      `json:"referenceId"`
+	DisplayName   string      `json:"displayName"`
+	BankShortCode BankCode    `json:"bankShortCode,omitempty"` // va
+	AccountNo     string      `json:"accountNo,omitempty"`     // va
+	ImageURL      string      `json:"imageUrl,omitempty"`      // qris
 }
 
 // CreatePaymentMethod to create new payment method.
@@ -24,26 +24,48 @@ func (c *Client) CreatePaymentMethod(request CreatePaymentMethodRequest) (*Payme
 
 // CreatePaymentMethodWithContext to create new payment with context.
 func (c *Client) CreatePaymentMethodWithContext(ctx context.Context, request CreatePaymentMethodRequest) (*PaymentMethod, int, error) {
+	ctx, span := c.startSpan(ctx, OpCreatePaymentMethod,
+		attrReferenceID.String(request.ReferenceID),
+		attrPaymentType.String(string(request.Type)),
+		attrBankCode.String(string(request.BankShortCode)),
+	)
+	defer span.End()
+
 	if err := request.validate(); err != nil {
 		return nil, http.StatusBadRequest, err
 	}
 
-	var response paymentMethod
-	code, err := c.requester.Call(
-		ctx,
-		http.MethodPost,
-		fmt.Sprintf("%s/payment_methods/%s", c.baseURL, request.Type.toURL()),
-		c.apiKey,
-		c.secretKey,
-		nil,
-		request.wrap(),
-		&response,
-	)
-	if err != nil {
-		return nil, code, err
+	ctx = withReferenceID(ctx, request.ReferenceID)
+
+	create := func() (*PaymentMethod, int, error) {
+		var response document[resource[paymentMethodAttributes]]
+		code, err := c.requester.Call(
+			ctx,
+			http.MethodPost,
+			fmt.Sprintf("%s/payment_methods/%s", c.baseURL, request.Type.toURL()),
+			c.apiKey,
+			c.secretKey,
+			idempotencyHeader(request.IdempotencyKey),
+			newDocument(attributesOf(request)),
+			&response,
+		)
+		if err != nil {
+			return nil, code, err
+		}
+		setSpanAttributes(ctx, attrResourceID.String(response.Data.ID))
+		res := toPaymentMethod(response.Data)
+		return &res, code, nil
+	}
+
+	// Xfers doesn't provide payment method list endpoint to look up
+	// the payment method. Re-issuing create is only safe with
+	// idempotency key.
+	if request.IdempotencyKey == "" {
+		return create()
 	}
 
-	return response.toPaymentMethod(), code, nil
+	res, code, _, err := createIdempotent(ctx, c.idempotency, create, nil, nil)
+	return res, code, err
 }
 
 // GetPaymentMethod to get payment method.
@@ -53,11 +75,14 @@ func (c *Client) GetPaymentMethod(request GetPaymentMethodRequest) (*PaymentMeth
 
 // GetPaymentMethodWithContext to get payment with context.
 func (c *Client) GetPaymentMethodWithContext(ctx context.Context, request GetPaymentMethodRequest) (*PaymentMethod, int, error) {
+	ctx, span := c.startSpan(ctx, OpGetPaymentMethod, attrResourceID.String(request.ID), attrPaymentType.String(string(request.Type)))
+	defer span.End()
+
 	if err := validate(&request); err != nil {
 		return nil, http.StatusBadRequest, err
 	}
 
-	var response paymentMethod
+	var response document[resource[paymentMethodAttributes]]
 	code, err := c.requester.Call(
 		ctx,
 		http.MethodGet,
@@ -72,7 +97,8 @@ func (c *Client) GetPaymentMethodWithContext(ctx context.Context, request GetPay
 		return nil, code, err
 	}
 
-	return response.toPaymentMethod(), code, nil
+	res := toPaymentMethod(response.Data)
+	return &res, code, nil
 }
 
 // GetPaymentMethods to get payment method list.
@@ -82,15 +108,23 @@ func (c *Client) GetPaymentMethods(request GetPaymentMethodRequest, pagination P
 
 // GetPaymentMethodsWithContext to get payment method list with context.
 func (c *Client) GetPaymentMethodsWithContext(ctx context.Context, request GetPaymentMethodRequest, pagination Pagination) ([]Payment, int, error) {
+	payments, _, code, err := c.getPaymentMethodPayments(ctx, request, pagination)
+	return payments, code, err
+}
+
+func (c *Client) getPaymentMethodPayments(ctx context.Context, request GetPaymentMethodRequest, pagination Pagination) ([]Payment, PageInfo, int, error) {
+	ctx, span := c.startSpan(ctx, OpGetPaymentMethods, attrResourceID.String(request.ID), attrPaymentType.String(string(request.Type)))
+	defer span.End()
+
 	if err := validate(&request); err != nil {
-		return nil, http.StatusBadRequest, err
+		return nil, PageInfo{}, http.StatusBadRequest, err
 	}
 
 	if err := validate(&pagination); err != nil {
-		return nil, http.StatusBadRequest, err
+		return nil, PageInfo{}, http.StatusBadRequest, err
 	}
 
-	var response payments
+	var response document[[]resource[paymentAttributes]]
 	code, err := c.requester.Call(
 		ctx,
 		http.MethodGet,
@@ -102,17 +136,17 @@ func (c *Client) GetPaymentMethodsWithContext(ctx context.Context, request GetPa
 		&response,
 	)
 	if err != nil {
-		return nil, code, err
+		return nil, PageInfo{}, code, err
 	}
 
-	return response.toPayments(), code, nil
+	return mapResources(response.Data, toPayment), response.pageInfo(pagination, len(response.Data)), code, nil
 }
 
 // PaymentMethodAction is response model from simulate payment method.
 type PaymentMethodAction struct {
-	TargetID   string
-	TargetType string
-	Name       Action
+	TargetID   string `json:"targetId"`
+	TargetType string `json:"targetType"`
+	Name       Action `json:"action"`
 }
 
 // SimulatePaymentMethod to simulate payment method status. Sandbox only.
@@ -122,6 +156,9 @@ func (c *Client) SimulatePaymentMethod(request SimulatePaymentMethodRequest) (*P
 
 // SimulatePaymentWithMethodContext to simulate payment method status with context. Sandbox only.
 func (c *Client) SimulatePaymentWithMethodContext(ctx context.Context, request SimulatePaymentMethodRequest) (*PaymentMethodAction, int, error) {
+	ctx, span := c.startSpan(ctx, OpSimulatePaymentMethod, attrResourceID.String(request.ID), attrPaymentType.String(string(request.Type)))
+	defer span.End()
+
 	if c.env == Production {
 		return nil, http.StatusBadRequest, ErrSandboxOnly
 	}
@@ -130,7 +167,7 @@ func (c *Client) SimulatePaymentWithMethodContext(ctx context.Context, request S
 		return nil, http.StatusBadRequest, err
 	}
 
-	var response paymentMethodAction
+	var response document[resource[actionAttributes]]
 	code, err := c.requester.Call(
 		ctx,
 		http.MethodPost,
@@ -138,12 +175,13 @@ func (c *Client) SimulatePaymentWithMethodContext(ctx context.Context, request S
 		c.apiKey,
 		c.secretKey,
 		nil,
-		request.wrap(),
+		newDocument(attributesOf(request)),
 		&response,
 	)
 	if err != nil {
 		return nil, code, err
 	}
 
-	return response.toPaymentMethodAction(), code, nil
+	res := toPaymentMethodAction(response.Data)
+	return &res, code, nil
 }
@@ -0,0 +1,395 @@
+package xfers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrApprovalNotFound is error when the approval does not exist.
+	ErrApprovalNotFound = errors.New("approval not found")
+	// ErrApprovalClosed is error when approving or rejecting approval
+	// which is not pending anymore.
+	ErrApprovalClosed = errors.New("approval is not pending")
+	// ErrSelfApproval is error when the maker approves their own request.
+	ErrSelfApproval = errors.New("maker cannot approve own request")
+	// ErrDuplicateApprover is error when the same approver approves twice.
+	ErrDuplicateApprover = errors.New("approver already approved")
+)
+
+// ApprovalThreshold is number of approvals required for disbursement
+// with amount at least MinAmount.
+type ApprovalThreshold struct {
+	MinAmount Amount
+	Approvals int
+}
+
+// ApprovalOption is config for disbursement approvals.
+type ApprovalOption struct {
+	// Storage for the approvals. Default is in-memory store.
+	Store ApprovalStore
+	// Required approvals by amount. The highest matching threshold
+	// is used. Disbursement not matching any threshold is sent
+	// immediately when submitted.
+	Thresholds []ApprovalThreshold
+}
+
+// ApprovalAuditEntry is a single action on an approval.
+type ApprovalAuditEntry struct {
+	Time   time.Time
+	Actor  string
+	Action ApprovalAction
+	Note   string
+}
+
+// DisbursementApproval is disbursement waiting for approval.
+type DisbursementApproval struct {
+	ID                string
+	Request           CreateDisbursementRequest
+	Maker             string
+	Status            ApprovalStatus
+	RequiredApprovals int
+	Approvers         []string
+	// Created disbursement if the status is ApprovalSent.
+	Disbursement *Disbursement
+	// Error message if the status is ApprovalFailed.
+	Error     string
+	Audit     []ApprovalAuditEntry
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (d *DisbursementApproval) clone() *DisbursementApproval {
+	res := *d
+	res.Approvers = append([]string{}, d.Approvers...)
+	res.Audit = append([]ApprovalAuditEntry{}, d.Audit...)
+	if d.Disbursement != nil {
+		disbursement := *d.Disbursement
+		res.Disbursement = &disbursement
+	}
+	return &res
+}
+
+func (d *DisbursementApproval) log(actor string, action ApprovalAction, note string) {
+	d.UpdatedAt = time.Now()
+	d.Audit = append(d.Audit, ApprovalAuditEntry{
+		Time:   d.UpdatedAt,
+		Actor:  actor,
+		Action: action,
+		Note:   note,
+	})
+}
+
+// ApprovalStore is storage for disbursement approvals.
+type ApprovalStore interface {
+	Create(ctx context.Context, approval *DisbursementApproval) error
+	// Get returns ErrApprovalNotFound if not exist.
+	Get(ctx context.Context, id string) (*DisbursementApproval, error)
+	Update(ctx context.Context, approval *DisbursementApproval) error
+	// List approvals with the status. Empty status means all.
+	List(ctx context.Context, status ApprovalStatus) ([]DisbursementApproval, error)
+}
+
+type memoryApprovalStore struct {
+	mu        sync.Mutex
+	approvals map[string]*DisbursementApproval
+}
+
+// NewMemoryApprovalStore to create in-memory approval store.
+func NewMemoryApprovalStore() ApprovalStore {
+	return &memoryApprovalStore{
+		approvals: make(map[string]*DisbursementApproval),
+	}
+}
+
+// Create to create approval.
+func (m *memoryApprovalStore) Create(ctx context.Context, approval *DisbursementApproval) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.approvals[approval.ID] = approval.clone()
+	return nil
+}
+
+// Get to get approval.
+func (m *memoryApprovalStore) Get(ctx context.Context, id string) (*DisbursementApproval, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	a, ok := m.approvals[id]
+	if !ok {
+		return nil, ErrApprovalNotFound
+	}
+	return a.clone(), nil
+}
+
+// Update to update approval.
+func (m *memoryApprovalStore) Update(ctx context.Context, approval *DisbursementApproval) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.approvals[approval.ID]; !ok {
+		return ErrApprovalNotFound
+	}
+	m.approvals[approval.ID] = approval.clone()
+	return nil
+}
+
+// List to get approval list.
+func (m *memoryApprovalStore) List(ctx context.Context, status ApprovalStatus) ([]DisbursementApproval, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var res []DisbursementApproval
+	for _, a := range m.approvals {
+		if status == "" || a.Status == status {
+			res = append(res, *a.clone())
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].CreatedAt.Before(res[j].CreatedAt)
+	})
+
+	return res, nil
+}
+
+// DisbursementApprovals is maker-checker workflow for disbursements.
+// Approved disbursements are sent with CreateDisbursementWithContext.
+type DisbursementApprovals struct {
+	client     *Client
+	store      ApprovalStore
+	thresholds []ApprovalThreshold
+
+	// Only guards read-modify-write in this process.
+	mu sync.Mutex
+}
+
+// NewDisbursementApprovals to create new disbursement approval workflow.
+func NewDisbursementApprovals(client *Client, option ApprovalOption) *DisbursementApprovals {
+	if option.Store == nil {
+		option.Store = NewMemoryApprovalStore()
+	}
+
+	return &DisbursementApprovals{
+		client:     client,
+		store:      option.Store,
+		thresholds: option.Thresholds,
+	}
+}
+
+func (a *DisbursementApprovals) requiredApprovals(amount Amount) int {
+	var res int
+	var minAmount Amount
+	for _, t := range a.thresholds {
+		if amount.Cmp(t.MinAmount) >= 0 && (res == 0 || t.MinAmount.Cmp(minAmount) > 0) {
+			res, minAmount = t.Approvals, t.MinAmount
+		}
+	}
+	return res
+}
+
+// Submit to stage disbursement for approval. The disbursement is sent
+// immediately if no approval is required.
+func (a *DisbursementApprovals) Submit(ctx context.Context, maker string, request CreateDisbursementRequest) (*DisbursementApproval, error) {
+	if maker == "" {
+		return nil, errRequiredField("maker")
+	}
+
+	if err := validate(&request); err != nil {
+		return nil, err
+	}
+
+	id, err := newApprovalID()
+	if err != nil {
+		return nil, err
+	}
+
+	approval := &DisbursementApproval{
+		ID:                id,
+		Request:           request,
+		Maker:             maker,
+		Status:            ApprovalPending,
+		RequiredApprovals: a.requiredApprovals(request.Amount),
+		CreatedAt:         time.Now(),
+	}
+	approval.log(maker, ApprovalActionSubmit, "")
+
+	if err := a.store.Create(ctx, approval); err != nil {
+		return nil, err
+	}
+
+	if approval.RequiredApprovals > 0 {
+		return approval, nil
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.send(ctx, approval, maker)
+}
+
+// Approve to approve the disbursement. The disbursement is sent when
+// the required approvals are met.
+func (a *DisbursementApprovals) Approve(ctx context.Context, id, approver, note string) (*DisbursementApproval, error) {
+	if approver == "" {
+		return nil, errRequiredField("approver")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	approval, err := a.store.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if approval.Status != ApprovalPending {
+		return approval, ErrApprovalClosed
+	}
+
+	if approver == approval.Maker {
+		return approval, ErrSelfApproval
+	}
+
+	for _, ap := range approval.Approvers {
+		if ap == approver {
+			return approval, ErrDuplicateApprover
+		}
+	}
+
+	approval.Approvers = append(approval.Approvers, approver)
+	approval.log(approver, ApprovalActionApprove, note)
+
+	if len(approval.Approvers) < approval.RequiredApprovals {
+		if err := a.store.Update(ctx, approval); err != nil {
+			return nil, err
+		}
+		return approval, nil
+	}
+
+	return a.send(ctx, approval, approver)
+}
+
+// Reject to reject the disbursement.
+func (a *DisbursementApprovals) Reject(ctx context.Context, id, approver, note string) (*DisbursementApproval, error) {
+	if approver == "" {
+		return nil, errRequiredField("approver")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	approval, err := a.store.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if approval.Status != ApprovalPending {
+		return approval, ErrApprovalClosed
+	}
+
+	if approver == approval.Maker {
+		return approval, ErrSelfApproval
+	}
+
+	approval.Status = ApprovalRejected
+	approval.log(approver, ApprovalActionReject, note)
+
+	if err := a.store.Update(ctx, approval); err != nil {
+		return nil, err
+	}
+
+	return approval, nil
+}
+
+// Resend to send approved disbursement which failed to be sent, or
+// was left approved because of crash while sending. The disbursement
+// is looked up by its reference id first so it is not sent twice.
+func (a *DisbursementApprovals) Resend(ctx context.Context, id, actor string) (*DisbursementApproval, error) {
+	if actor == "" {
+		return nil, errRequiredField("actor")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	approval, err := a.store.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if approval.Status != ApprovalFailed && approval.Status != ApprovalApproved {
+		return approval, ErrApprovalClosed
+	}
+
+	existing, err := a.client.lookupDisbursement(ctx, approval.Request.ReferenceID)
+	if err != nil {
+		return approval, err
+	}
+
+	if existing == nil {
+		return a.send(ctx, approval, actor)
+	}
+
+	if err := matchDisbursement(approval.Request, existing); err != nil {
+		return a.finish(ctx, approval, actor, nil, err)
+	}
+
+	return a.finish(ctx, approval, actor, existing, nil)
+}
+
+// Get to get approval.
+func (a *DisbursementApprovals) Get(ctx context.Context, id string) (*DisbursementApproval, error) {
+	return a.store.Get(ctx, id)
+}
+
+// List to get approval list by status. Empty status means all.
+func (a *DisbursementApprovals) List(ctx context.Context, status ApprovalStatus) ([]DisbursementApproval, error) {
+	return a.store.List(ctx, status)
+}
+
+func (a *DisbursementApprovals) send(ctx context.Context, approval *DisbursementApproval, actor string) (*DisbursementApproval, error) {
+	// Mark as approved first so a crash while sending does not
+	// leave the approval pending. It can be recovered with Resend.
+	approval.Status = ApprovalApproved
+	if err := a.store.Update(ctx, approval); err != nil {
+		return nil, err
+	}
+
+	disbursement, _, err := a.client.CreateDisbursementWithContext(ctx, approval.Request)
+	return a.finish(ctx, approval, actor, disbursement, err)
+}
+
+// finish to record the send result.
+func (a *DisbursementApprovals) finish(ctx context.Context, approval *DisbursementApproval, actor string, disbursement *Disbursement, err error) (*DisbursementApproval, error) {
+	if err != nil {
+		approval.Status = ApprovalFailed
+		approval.Error = err.Error()
+		approval.log(actor, ApprovalActionSendFailed, err.Error())
+	} else {
+		approval.Status = ApprovalSent
+		approval.Error = ""
+		approval.Disbursement = disbursement
+		approval.log(actor, ApprovalActionSend, disbursement.ID)
+	}
+
+	if uErr := a.store.Update(context.WithoutCancel(ctx), approval); uErr != nil {
+		a.client.logger.Error("approval store update %s: %s", approval.ID, uErr.Error())
+	}
+
+	return approval, err
+}
+
+func newApprovalID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
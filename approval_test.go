@@ -0,0 +1,86 @@
+package xfers_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/rl404/xfers-go"
+)
+
+func TestApprovalResendApproved(t *testing.T) {
+	tests := []struct {
+		name    string
+		created bool
+	}{
+		{name: "crash after create", created: true},
+		{name: "crash before create"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var posts int32
+			client, _ := newTestClient(t, xfers.Option{
+				Middlewares: []xfers.Middleware{dropResponse(0, &posts)},
+			})
+
+			ctx := context.Background()
+			store := xfers.NewMemoryApprovalStore()
+			approvals := xfers.NewDisbursementApprovals(client, xfers.ApprovalOption{
+				Store:      store,
+				Thresholds: []xfers.ApprovalThreshold{{Approvals: 1}},
+			})
+
+			request := disbursementRequest("r1", 100000)
+			approval, err := approvals.Submit(ctx, "maker", request)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Crash while sending leaves the approval approved.
+			approval.Status = xfers.ApprovalApproved
+			if err := store.Update(ctx, approval); err != nil {
+				t.Fatal(err)
+			}
+			if tt.created {
+				if _, _, err := client.CreateDisbursement(request); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if _, err := approvals.Approve(ctx, approval.ID, "checker", ""); err != xfers.ErrApprovalClosed {
+				t.Fatalf("got %v, want %v", err, xfers.ErrApprovalClosed)
+			}
+
+			approval, err = approvals.Resend(ctx, approval.ID, "checker")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if approval.Status != xfers.ApprovalSent || approval.Disbursement == nil {
+				t.Fatalf("got %+v, want sent", approval)
+			}
+			if posts != 1 {
+				t.Fatalf("got %d create requests, want 1", posts)
+			}
+		})
+	}
+}
+
+func TestApprovalJSONKeepsRequest(t *testing.T) {
+	request := disbursementRequest("r1", 100000)
+	request.IdempotencyKey = "key-1"
+
+	b, err := json.Marshal(xfers.DisbursementApproval{Request: request})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var approval xfers.DisbursementApproval
+	if err := json.Unmarshal(b, &approval); err != nil {
+		t.Fatal(err)
+	}
+
+	if approval.Request != request {
+		t.Fatalf("got %+v, want %+v", approval.Request, request)
+	}
+}
@@ -0,0 +1,160 @@
+package xfers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// BalanceGuardPolicy is config for checking available balance before
+// creating disbursement.
+type BalanceGuardPolicy struct {
+	Enabled bool
+	// How long the fetched balance is used. Default is 5 seconds.
+	CacheTTL time.Duration
+	// Balance that must be left after the disbursement.
+	MinBalance Amount
+	// Fee of each disbursement which is also deducted from the
+	// balance. It is added to the amount when checking the balance.
+	FeeAllowance Amount
+}
+
+// InsufficientBalanceError is returned by balance guard when the
+// available balance is not enough. It matches ErrInsufficientBalance
+// with errors.Is.
+type InsufficientBalanceError struct {
+	Available Amount
+	// Amount of the other disbursements in progress.
+	Reserved  Amount
+	Requested Amount
+	// Fee allowance added to the requested amount.
+	Fee       Amount
+	Shortfall Amount
+}
+
+// Error to get error message.
+func (e *InsufficientBalanceError) Error() string {
+	return fmt.Sprintf("insufficient balance: available %s, reserved %s, requested %s, fee %s, shortfall %s",
+		e.Available, e.Reserved, e.Requested, e.Fee, e.Shortfall)
+}
+
+// Is to match ErrInsufficientBalance.
+func (e *InsufficientBalanceError) Is(target error) bool {
+	return target == ErrInsufficientBalance
+}
+
+type balanceGuard struct {
+	policy BalanceGuardPolicy
+
+	mu        sync.Mutex
+	available Amount
+	reserved  Amount
+	fetchedAt time.Time
+	// Closed when the balance fetch in progress is done.
+	fetching chan struct{}
+}
+
+func newBalanceGuard(policy BalanceGuardPolicy) *balanceGuard {
+	if !policy.Enabled {
+		return nil
+	}
+
+	if policy.CacheTTL <= 0 {
+		policy.CacheTTL = 5 * time.Second
+	}
+
+	return &balanceGuard{policy: policy}
+}
+
+// reserveBalance to check the available balance and reserve the amount
+// until the returned release func is called with the create result.
+func (c *Client) reserveBalance(ctx context.Context, amount Amount) (func(err error), int, error) {
+	g := c.balanceGuard
+	if g == nil {
+		return func(error) {}, http.StatusOK, nil
+	}
+
+	if code, err := c.fetchBalance(ctx); err != nil {
+		return nil, code, err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	total := amount.Add(g.policy.FeeAllowance)
+
+	left := g.available.Sub(g.reserved).Sub(g.policy.MinBalance).Sub(total)
+	if left.IsNegative() {
+		return nil, http.StatusUnprocessableEntity, &InsufficientBalanceError{
+			Available: g.available,
+			Reserved:  g.reserved,
+			Requested: amount,
+			Fee:       g.policy.FeeAllowance,
+			Shortfall: left.Neg(),
+		}
+	}
+
+	g.reserved = g.reserved.Add(total)
+
+	return func(err error) {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+
+		g.reserved = g.reserved.Sub(total)
+
+		switch {
+		case err == nil:
+			g.available = g.available.Sub(total)
+		case isAmbiguous(err), IsInsufficientBalance(err):
+			// Not sure if the money is sent or the cached balance
+			// is outdated, fetch the balance again.
+			g.fetchedAt = time.Time{}
+		}
+	}, http.StatusOK, nil
+}
+
+// fetchBalance to fetch the balance if the cache is expired. Only one
+// request is sent at a time, the others wait for its result without
+// holding the lock.
+func (c *Client) fetchBalance(ctx context.Context) (int, error) {
+	g := c.balanceGuard
+
+	for {
+		g.mu.Lock()
+
+		if time.Since(g.fetchedAt) <= g.policy.CacheTTL {
+			g.mu.Unlock()
+			return http.StatusOK, nil
+		}
+
+		if fetching := g.fetching; fetching != nil {
+			g.mu.Unlock()
+
+			select {
+			case <-fetching:
+				continue
+			case <-ctx.Done():
+				return http.StatusInternalServerError, ctx.Err()
+			}
+		}
+
+		fetching := make(chan struct{})
+		g.fetching = fetching
+		g.mu.Unlock()
+
+		balance, code, err := c.GetBalanceWithContext(ctx)
+
+		g.mu.Lock()
+		if err == nil {
+			g.available = balance.AvailableBalance
+			g.fetchedAt = time.Now()
+		}
+		g.fetching = nil
+		close(fetching)
+		g.mu.Unlock()
+
+		return code, err
+	}
+}
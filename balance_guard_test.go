@@ -0,0 +1,82 @@
+package xfers_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rl404/xfers-go"
+)
+
+func TestBalanceGuardFee(t *testing.T) {
+	client, srv := newTestClient(t, xfers.Option{
+		BalanceGuard: xfers.BalanceGuardPolicy{
+			Enabled:      true,
+			FeeAllowance: xfers.NewAmount(5000),
+		},
+	})
+	if err := srv.SetBalance("100000"); err != nil {
+		t.Fatal(err)
+	}
+
+	_, code, err := client.CreateDisbursement(disbursementRequest("r1", 96000))
+
+	var bErr *xfers.InsufficientBalanceError
+	if !errors.As(err, &bErr) || code != http.StatusUnprocessableEntity {
+		t.Fatalf("got %v %d, want insufficient balance", err, code)
+	}
+	if bErr.Shortfall != xfers.NewAmount(1000) || bErr.Fee != xfers.NewAmount(5000) {
+		t.Fatalf("got %+v", bErr)
+	}
+
+	if _, _, err := client.CreateDisbursement(disbursementRequest("r2", 95000)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBalanceGuardSingleFetch(t *testing.T) {
+	var fetches int32
+	countFetch := func(next xfers.Requester) xfers.Requester {
+		return xfers.RequesterFunc(func(ctx context.Context, method, url, apiKey, secretKey string, header http.Header, request interface{}, response interface{}) (int, error) {
+			if strings.HasSuffix(url, "/balance_overview") {
+				atomic.AddInt32(&fetches, 1)
+				time.Sleep(20 * time.Millisecond)
+			}
+			return next.Call(ctx, method, url, apiKey, secretKey, header, request, response)
+		})
+	}
+
+	client, _ := newTestClient(t, xfers.Option{
+		BalanceGuard: xfers.BalanceGuardPolicy{Enabled: true},
+		Middlewares:  []xfers.Middleware{countFetch},
+	})
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, _, err := client.CreateDisbursement(disbursementRequest(fmt.Sprintf("r%d", i), 10000))
+			errs <- err
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if fetches != 1 {
+		t.Fatalf("got %d balance requests, want 1", fetches)
+	}
+}
@@ -0,0 +1,239 @@
+package xfers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is error when the request is rejected because
+// the circuit breaker is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitState is type for circuit breaker state.
+type CircuitState int8
+
+// Available options for CircuitState.
+const (
+	CircuitClosed CircuitState = iota
+	CircuitOpen
+	CircuitHalfOpen
+)
+
+// String to get the state name.
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// CircuitBreakerPolicy is config for circuit breaker.
+type CircuitBreakerPolicy struct {
+	// Consecutive failures to open the circuit. 0 means disabled.
+	// Failures are network errors, 5xx and 429 responses.
+	FailureThreshold int
+	// Duration the circuit stays open before allowing trial
+	// requests (half-open). Default is 30 seconds.
+	OpenTimeout time.Duration
+	// Successful trial requests needed to close the circuit.
+	// Default is 1.
+	HalfOpenMaxCalls int
+}
+
+// CircuitBreaker is closed/open/half-open circuit breaker.
+type CircuitBreaker struct {
+	policy CircuitBreakerPolicy
+
+	mu        sync.Mutex
+	state     CircuitState
+	failures  int
+	successes int
+	inFlight  int
+	openedAt  time.Time
+	// Incremented on every state change so results of requests
+	// admitted in the previous state are ignored.
+	generation uint64
+}
+
+// NewCircuitBreaker to create new circuit breaker.
+// Returns nil if the policy is disabled.
+func NewCircuitBreaker(policy CircuitBreakerPolicy) *CircuitBreaker {
+	if policy.FailureThreshold <= 0 {
+		return nil
+	}
+
+	if policy.OpenTimeout <= 0 {
+		policy.OpenTimeout = 30 * time.Second
+	}
+
+	if policy.HalfOpenMaxCalls <= 0 {
+		policy.HalfOpenMaxCalls = 1
+	}
+
+	return &CircuitBreaker{
+		policy: policy,
+	}
+}
+
+// State to get current circuit state. Nil breaker is always closed.
+func (b *CircuitBreaker) State() CircuitState {
+	if b == nil {
+		return CircuitClosed
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refresh()
+	return b.state
+}
+
+// refresh to move open state to half-open after the timeout.
+func (b *CircuitBreaker) refresh() {
+	if b.state == CircuitOpen && time.Since(b.openedAt) >= b.policy.OpenTimeout {
+		b.setState(CircuitHalfOpen)
+	}
+}
+
+// allow to check if the request can be sent. The returned done func
+// should be called with the request result.
+func (b *CircuitBreaker) allow() (func(failed bool), bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refresh()
+
+	switch b.state {
+	case CircuitOpen:
+		return nil, false
+	case CircuitHalfOpen:
+		if b.inFlight >= b.policy.HalfOpenMaxCalls {
+			return nil, false
+		}
+		b.inFlight++
+	}
+
+	generation := b.generation
+	return func(failed bool) {
+		b.done(generation, failed)
+	}, true
+}
+
+// done to record the request result.
+func (b *CircuitBreaker) done(generation uint64, failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if generation != b.generation {
+		return
+	}
+
+	switch b.state {
+	case CircuitClosed:
+		if !failed {
+			b.failures = 0
+			return
+		}
+		b.failures++
+		if b.failures >= b.policy.FailureThreshold {
+			b.open()
+		}
+	case CircuitHalfOpen:
+		b.inFlight--
+		if failed {
+			b.open()
+			return
+		}
+		b.successes++
+		if b.successes >= b.policy.HalfOpenMaxCalls {
+			b.setState(CircuitClosed)
+		}
+	}
+}
+
+func (b *CircuitBreaker) open() {
+	b.setState(CircuitOpen)
+	b.openedAt = time.Now()
+}
+
+func (b *CircuitBreaker) setState(state CircuitState) {
+	b.state = state
+	b.generation++
+	b.failures = 0
+	b.successes = 0
+	b.inFlight = 0
+}
+
+func isBreakerFailure(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= http.StatusInternalServerError || apiErr.StatusCode == http.StatusTooManyRequests
+	}
+
+	return errors.Is(err, ErrInternal) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// isReadRequest to check if the request only reads data.
+func isReadRequest(ctx context.Context, method string) bool {
+	if method == http.MethodGet {
+		return true
+	}
+	idempotent, _ := ctx.Value(idempotentKey{}).(bool)
+	return idempotent
+}
+
+// CircuitBreakerMiddleware to create middleware failing fast with
+// ErrCircuitOpen when the circuit is open. Read requests (GET and
+// validating bank account) and write requests use separate breakers.
+// Nil breaker means no circuit breaker for the request type.
+func CircuitBreakerMiddleware(read, write *CircuitBreaker) Middleware {
+	return func(next Requester) Requester {
+		if read == nil && write == nil {
+			return next
+		}
+
+		return RequesterFunc(func(ctx context.Context, method, url, apiKey, secretKey string, header http.Header, request interface{}, response interface{}) (int, error) {
+			b := write
+			if isReadRequest(ctx, method) {
+				b = read
+			}
+
+			if b == nil {
+				return next.Call(ctx, method, url, apiKey, secretKey, header, request, response)
+			}
+
+			done, ok := b.allow()
+			if !ok {
+				return http.StatusServiceUnavailable, ErrCircuitOpen
+			}
+
+			code, err := next.Call(ctx, method, url, apiKey, secretKey, header, request, response)
+			done(isBreakerFailure(err))
+
+			return code, err
+		})
+	}
+}
+
+// ReadCircuitState to get the read requests circuit breaker state.
+// Useful for health check.
+func (c *Client) ReadCircuitState() CircuitState {
+	return c.readBreaker.State()
+}
+
+// WriteCircuitState to get the write requests circuit breaker state.
+// Useful for health check.
+func (c *Client) WriteCircuitState() CircuitState {
+	return c.writeBreaker.State()
+}
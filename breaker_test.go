@@ -0,0 +1,89 @@
+package xfers_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/rl404/xfers-go"
+)
+
+// stubRequester to create requester returning the result received
+// from the channel of the request url.
+func stubRequester(results map[string]chan error) xfers.Requester {
+	return xfers.RequesterFunc(func(ctx context.Context, method, url, apiKey, secretKey string, header http.Header, request interface{}, response interface{}) (int, error) {
+		if err := <-results[url]; err != nil {
+			return http.StatusInternalServerError, err
+		}
+		return http.StatusOK, nil
+	})
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	breaker := xfers.NewCircuitBreaker(xfers.CircuitBreakerPolicy{
+		FailureThreshold: 2,
+		OpenTimeout:      20 * time.Millisecond,
+	})
+
+	results := map[string]chan error{
+		"slow":  make(chan error),
+		"fast":  make(chan error, 10),
+		"trial": make(chan error),
+	}
+	requester := xfers.Chain(stubRequester(results), xfers.CircuitBreakerMiddleware(nil, breaker))
+
+	call := func(url string) <-chan error {
+		ch := make(chan error, 1)
+		go func() {
+			_, err := requester.Call(context.Background(), http.MethodPost, url, "", "", nil, nil, nil)
+			ch <- err
+		}()
+		return ch
+	}
+
+	// Admitted while closed and finished after the state changes.
+	slow := call("slow")
+	time.Sleep(10 * time.Millisecond)
+
+	for i := 0; i < 2; i++ {
+		results["fast"] <- xfers.ErrInternal
+		<-call("fast")
+	}
+	if s := breaker.State(); s != xfers.CircuitOpen {
+		t.Fatalf("got %s, want open", s)
+	}
+
+	if err := <-call("fast"); !errors.Is(err, xfers.ErrCircuitOpen) {
+		t.Fatalf("got %v, want %v", err, xfers.ErrCircuitOpen)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if s := breaker.State(); s != xfers.CircuitHalfOpen {
+		t.Fatalf("got %s, want half-open", s)
+	}
+
+	results["slow"] <- nil
+	if err := <-slow; err != nil {
+		t.Fatal(err)
+	}
+	if s := breaker.State(); s != xfers.CircuitHalfOpen {
+		t.Fatalf("got %s after stale success, want half-open", s)
+	}
+
+	trial := call("trial")
+	time.Sleep(10 * time.Millisecond)
+
+	if err := <-call("fast"); !errors.Is(err, xfers.ErrCircuitOpen) {
+		t.Fatalf("got %v, want only 1 trial request", err)
+	}
+
+	results["trial"] <- nil
+	if err := <-trial; err != nil {
+		t.Fatal(err)
+	}
+	if s := breaker.State(); s != xfers.CircuitClosed {
+		t.Fatalf("got %s, want closed", s)
+	}
+}
@@ -0,0 +1,302 @@
+package xfers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+)
+
+// BulkResult is result of a single bulk disbursement item.
+type BulkResult struct {
+	ReferenceID  string
+	Status       BulkStatus
+	Disbursement *Disbursement
+	StatusCode   int
+	Error        string
+	// True if the result comes from checkpoint or the disbursement
+	// was already created in the previous run.
+	Resumed bool
+}
+
+// BulkReport is result of bulk disbursement.
+type BulkReport struct {
+	Results []BulkResult
+	Count   map[BulkStatus]int
+}
+
+// BulkCheckpoint is storage for bulk disbursement progress so a
+// partially completed batch can be resumed without double paying.
+type BulkCheckpoint interface {
+	// Load returns nil if there is no checkpoint for the reference id.
+	Load(ctx context.Context, referenceID string) (*BulkResult, error)
+	Save(ctx context.Context, result BulkResult) error
+}
+
+type memoryCheckpoint struct {
+	mu      sync.Mutex
+	results map[string]BulkResult
+}
+
+// NewMemoryCheckpoint to create in-memory bulk checkpoint.
+func NewMemoryCheckpoint() BulkCheckpoint {
+	return &memoryCheckpoint{
+		results: make(map[string]BulkResult),
+	}
+}
+
+// Load to get checkpoint.
+func (m *memoryCheckpoint) Load(ctx context.Context, referenceID string) (*BulkResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	r, ok := m.results[referenceID]
+	if !ok {
+		return nil, nil
+	}
+	return &r, nil
+}
+
+// Save to save checkpoint.
+func (m *memoryCheckpoint) Save(ctx context.Context, result BulkResult) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.results[result.ReferenceID] = result
+	return nil
+}
+
+// BulkOption is config for bulk disbursement.
+type BulkOption struct {
+	// Max concurrent create disbursement. Default is 5.
+	Concurrency int
+	// Checkpoint to resume the batch. Default is in-memory
+	// checkpoint which only dedups within the call.
+	Checkpoint BulkCheckpoint
+	// Called after every item is processed. Must be safe for
+	// concurrent use.
+	OnResult func(result BulkResult)
+}
+
+// BulkDisburse to create disbursements concurrently. Results are
+// in the same order as the requests.
+//
+// Requests with the same reference id are only sent once. Items
+// already created according to the checkpoint are skipped. Items
+// left pending (e.g. the process crashed mid-request) are looked up
+// by reference id before being re-created.
+//
+// The returned error is only the context error. Item errors are
+// in the report.
+func (c *Client) BulkDisburse(ctx context.Context, requests []CreateDisbursementRequest, option BulkOption) (*BulkReport, error) {
+	ch := make(chan CreateDisbursementRequest)
+	go func() {
+		defer close(ch)
+		for _, r := range requests {
+			select {
+			case ch <- r:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	report, err := c.BulkDisburseStream(ctx, ch, option)
+
+	// Requests not yet read when the context is done.
+	for i := len(report.Results); i < len(requests); i++ {
+		report.add(BulkResult{
+			ReferenceID: requests[i].ReferenceID,
+			Status:      BulkCancelled,
+			Error:       ctx.Err().Error(),
+		})
+	}
+
+	return report, err
+}
+
+// BulkDisburseStream is the same as BulkDisburse but reading the
+// requests from channel until it is closed. Results are in the
+// same order as the requests are received.
+func (c *Client) BulkDisburseStream(ctx context.Context, requests <-chan CreateDisbursementRequest, option BulkOption) (*BulkReport, error) {
+	if option.Concurrency <= 0 {
+		option.Concurrency = 5
+	}
+
+	if option.Checkpoint == nil {
+		option.Checkpoint = NewMemoryCheckpoint()
+	}
+
+	type job struct {
+		i       int
+		request CreateDisbursementRequest
+	}
+
+	var mu sync.Mutex
+	results := make(map[int]BulkResult)
+	seen := make(map[string]bool)
+
+	jobs := make(chan job)
+	var wg sync.WaitGroup
+	for w := 0; w < option.Concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				r := c.bulkDisburse(ctx, j.request, option.Checkpoint)
+				if option.OnResult != nil {
+					option.OnResult(r)
+				}
+				mu.Lock()
+				results[j.i] = r
+				mu.Unlock()
+			}
+		}()
+	}
+
+	n := 0
+	func() {
+		defer close(jobs)
+		for {
+			var request CreateDisbursementRequest
+			var ok bool
+			select {
+			case request, ok = <-requests:
+				if !ok {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+
+			i := n
+			n++
+
+			if seen[request.ReferenceID] {
+				r := BulkResult{
+					ReferenceID: request.ReferenceID,
+					Status:      BulkDuplicate,
+					StatusCode:  http.StatusConflict,
+					Error:       "duplicate reference id in batch",
+				}
+				if option.OnResult != nil {
+					option.OnResult(r)
+				}
+				mu.Lock()
+				results[i] = r
+				mu.Unlock()
+				continue
+			}
+			seen[request.ReferenceID] = true
+
+			select {
+			case jobs <- job{i: i, request: request}:
+			case <-ctx.Done():
+				mu.Lock()
+				results[i] = BulkResult{ReferenceID: request.ReferenceID, Status: BulkCancelled, Error: ctx.Err().Error()}
+				mu.Unlock()
+				return
+			}
+		}
+	}()
+
+	wg.Wait()
+
+	report := &BulkReport{Count: make(map[BulkStatus]int)}
+	for i := 0; i < n; i++ {
+		report.add(results[i])
+	}
+
+	return report, ctx.Err()
+}
+
+func (r *BulkReport) add(result BulkResult) {
+	r.Results = append(r.Results, result)
+	r.Count[result.Status]++
+}
+
+func (c *Client) bulkDisburse(ctx context.Context, request CreateDisbursementRequest, checkpoint BulkCheckpoint) BulkResult {
+	result := BulkResult{ReferenceID: request.ReferenceID}
+
+	if err := ctx.Err(); err != nil {
+		result.Status, result.Error = BulkCancelled, err.Error()
+		return result
+	}
+
+	prev, err := checkpoint.Load(ctx, request.ReferenceID)
+	if err != nil {
+		result.Status, result.Error = BulkError, err.Error()
+		return result
+	}
+
+	if prev != nil {
+		switch prev.Status {
+		case BulkCreated, BulkDuplicate:
+			prev.Resumed = true
+			return *prev
+		case BulkPending, BulkAPIError, BulkError, BulkCancelled:
+			// The previous request may have been processed.
+			existing, err := c.lookupDisbursement(ctx, request.ReferenceID)
+			if err != nil {
+				result.Status, result.Error = BulkError, err.Error()
+				return result
+			}
+			if existing != nil {
+				result.Disbursement, result.Resumed = existing, true
+				// Normalize the request the same way as create does.
+				normalized := request
+				if err := mod.Struct(ctx, &normalized); err != nil {
+					result.Status, result.Error = BulkError, err.Error()
+					return result
+				}
+				if err := matchDisbursement(normalized, existing); err != nil {
+					result.Status, result.StatusCode, result.Error = BulkDuplicate, http.StatusConflict, err.Error()
+				} else {
+					result.Status, result.StatusCode = BulkCreated, http.StatusOK
+				}
+				return c.saveBulkResult(ctx, checkpoint, result)
+			}
+		}
+	}
+
+	if err := checkpoint.Save(ctx, BulkResult{ReferenceID: request.ReferenceID, Status: BulkPending}); err != nil {
+		result.Status, result.Error = BulkError, err.Error()
+		return result
+	}
+
+	disbursement, code, err := c.CreateDisbursementWithContext(ctx, request)
+	result.StatusCode = code
+
+	var apiErr *APIError
+	switch {
+	case err == nil:
+		result.Status, result.Disbursement = BulkCreated, disbursement
+	case IsDuplicateReference(err):
+		result.Status, result.Error = BulkDuplicate, err.Error()
+		if existing, lErr := c.lookupDisbursement(ctx, request.ReferenceID); lErr == nil {
+			result.Disbursement = existing
+		}
+	case errors.Is(err, ErrPolicyViolation):
+		result.Status, result.Error = BulkPolicyViolation, err.Error()
+	case IsInsufficientBalance(err):
+		result.Status, result.Error = BulkInsufficientBalance, err.Error()
+	case errors.As(err, &apiErr):
+		result.Status, result.Error = BulkAPIError, err.Error()
+	case code == http.StatusBadRequest:
+		result.Status, result.Error = BulkValidationError, err.Error()
+	case errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded):
+		result.Status, result.Error = BulkCancelled, err.Error()
+	default:
+		result.Status, result.Error = BulkError, err.Error()
+	}
+
+	return c.saveBulkResult(ctx, checkpoint, result)
+}
+
+func (c *Client) saveBulkResult(ctx context.Context, checkpoint BulkCheckpoint, result BulkResult) BulkResult {
+	// Save even if the context is done so the progress is not lost.
+	if err := checkpoint.Save(context.WithoutCancel(ctx), result); err != nil {
+		c.logger.Error("bulk checkpoint %s: %s", result.ReferenceID, err.Error())
+	}
+	return result
+}
@@ -0,0 +1,76 @@
+package xfers_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rl404/xfers-go"
+)
+
+func TestBulkDisburseResume(t *testing.T) {
+	var posts int32
+	client, srv := newTestClient(t, xfers.Option{
+		Middlewares: []xfers.Middleware{dropResponse(1, &posts)},
+	})
+
+	checkpoint := xfers.NewMemoryCheckpoint()
+	option := xfers.BulkOption{Concurrency: 1, Checkpoint: checkpoint}
+
+	requests := []xfers.CreateDisbursementRequest{
+		disbursementRequest("r1", 10000),
+		disbursementRequest("r2", 20000),
+		disbursementRequest("r2", 20000),
+	}
+
+	report, err := client.BulkDisburse(context.Background(), requests, option)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// r1 is created by xfers but the response is lost.
+	for i, want := range []xfers.BulkStatus{xfers.BulkError, xfers.BulkCreated, xfers.BulkDuplicate} {
+		if got := report.Results[i].Status; got != want {
+			t.Fatalf("first run %d: got %s, want %s", i, got, want)
+		}
+	}
+
+	posts = 0
+	client = xfers.New(xfers.Option{
+		APIKey:      "api-key",
+		SecretKey:   "secret-key",
+		BaseURL:     srv.URL,
+		Middlewares: []xfers.Middleware{dropResponse(0, &posts)},
+	})
+
+	requests = append(requests[:2], disbursementRequest("r3", 30000))
+	report, err = client.BulkDisburse(context.Background(), requests, option)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, want := range []struct {
+		status  xfers.BulkStatus
+		resumed bool
+	}{
+		{status: xfers.BulkCreated, resumed: true},
+		{status: xfers.BulkCreated, resumed: true},
+		{status: xfers.BulkCreated},
+	} {
+		r := report.Results[i]
+		if r.Status != want.status || r.Resumed != want.resumed || r.Disbursement == nil {
+			t.Fatalf("second run %d: got %+v, want %+v", i, r, want)
+		}
+	}
+
+	if posts != 1 {
+		t.Fatalf("got %d create requests, want 1", posts)
+	}
+
+	disbursements, _, err := client.GetDisbursements(xfers.Pagination{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(disbursements) != 3 {
+		t.Fatalf("got %d disbursements, want 3", len(disbursements))
+	}
+}
@@ -0,0 +1,66 @@
+package xfers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// Response is raw http response captured by WithResponse.
+type Response struct {
+	// Zero if no response is received (e.g. network error).
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+	RequestID  string
+	// Latency of the last attempt.
+	Latency time.Duration
+	Attempt int
+	// Parsed body. Nil if the body is not a JSON:API document.
+	Document *Document
+	// Error of the request if any.
+	Err error
+}
+
+type responseKey struct{}
+
+// WithResponse to capture the raw response of the call using the
+// returned context. If the call makes several requests (e.g. retry,
+// idempotent lookup or balance check), the last one is captured.
+// Only the default requester fills the response. Do not share the
+// context between concurrent calls.
+//
+//	var resp xfers.Response
+//	payment, _, err := client.GetPaymentWithContext(xfers.WithResponse(ctx, &resp), id)
+//	fmt.Println(resp.RequestID, resp.Document.Meta)
+func WithResponse(ctx context.Context, resp *Response) context.Context {
+	return context.WithValue(ctx, responseKey{}, resp)
+}
+
+func captureResponse(ctx context.Context, code int, header http.Header, body []byte, latency time.Duration, err error) {
+	resp, ok := ctx.Value(responseKey{}).(*Response)
+	if !ok || resp == nil {
+		return
+	}
+
+	// Header is only nil if there is no response.
+	if header == nil {
+		code = 0
+	}
+
+	*resp = Response{
+		StatusCode: code,
+		Header:     header,
+		Body:       body,
+		RequestID:  requestID(header),
+		Latency:    latency,
+		Attempt:    attemptFromContext(ctx),
+		Err:        err,
+	}
+
+	var doc Document
+	if err := json.Unmarshal(body, &doc); err == nil {
+		resp.Document = &doc
+	}
+}
@@ -0,0 +1,42 @@
+package xfers_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/rl404/xfers-go"
+)
+
+func TestWithResponse(t *testing.T) {
+	client, _ := newTestClient(t, xfers.Option{})
+
+	var resp xfers.Response
+	if _, _, err := client.CreateDisbursementWithContext(xfers.WithResponse(context.Background(), &resp), disbursementRequest("r1", 10000)); err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusCreated || resp.Err != nil || resp.Document == nil || len(resp.Body) == 0 {
+		t.Fatalf("got %+v, want created response", resp)
+	}
+
+	if _, _, err := client.GetDisbursementWithContext(xfers.WithResponse(context.Background(), &resp), "missing"); !xfers.IsNotFound(err) {
+		t.Fatalf("got %v, want not found", err)
+	}
+	if resp.StatusCode != http.StatusNotFound || !xfers.IsNotFound(resp.Err) {
+		t.Fatalf("got %d %v, want %d", resp.StatusCode, resp.Err, http.StatusNotFound)
+	}
+}
+
+func TestWithResponseNoResponse(t *testing.T) {
+	client := xfers.New(xfers.Option{BaseURL: "http://127.0.0.1:1"})
+
+	var resp xfers.Response
+	if _, _, err := client.GetBalanceWithContext(xfers.WithResponse(context.Background(), &resp)); !errors.Is(err, xfers.ErrInternal) {
+		t.Fatalf("got %v, want %v", err, xfers.ErrInternal)
+	}
+
+	if resp.StatusCode != 0 || resp.Header != nil || resp.Document != nil || !errors.Is(resp.Err, xfers.ErrInternal) {
+		t.Fatalf("got %+v, want no response with error", resp)
+	}
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rl404/xfers-go"
+)
+
+var envURL = map[string]string{
+	"sandbox":    "https://sandbox-id.xfers.com/api/v4",
+	"production": "https://id.xfers.com/api/v4",
+}
+
+// config is common flags of every command.
+type config struct {
+	profile string
+	env     string
+	baseURL string
+	output  string
+	debug   bool
+}
+
+func newFlagSet(name string) (*flag.FlagSet, *config) {
+	var cfg config
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.StringVar(&cfg.profile, "profile", "", "profile name in config file")
+	fs.StringVar(&cfg.env, "env", "", "sandbox or production")
+	fs.StringVar(&cfg.baseURL, "base-url", "", "override API base URL")
+	fs.StringVar(&cfg.output, "o", "table", "output format: table, json or csv")
+	fs.BoolVar(&cfg.debug, "debug", false, "print request & response log to stderr")
+	return fs, &cfg
+}
+
+// client to create xfers client from flags, env vars and profile file.
+func (c *config) client() (*xfers.Client, error) {
+	if _, ok := printers[c.output]; !ok {
+		return nil, fmt.Errorf("invalid output format %q", c.output)
+	}
+
+	profileName := firstNonEmpty(c.profile, os.Getenv("XFERS_PROFILE"))
+
+	profile, err := loadProfile(firstNonEmpty(profileName, "default"))
+	if err != nil {
+		return nil, err
+	}
+
+	// Explicitly selected profile is not mixed with the env vars.
+	getenv := os.Getenv
+	if profileName != "" {
+		getenv = func(string) string { return "" }
+	}
+
+	apiKey := firstNonEmpty(getenv("XFERS_API_KEY"), profile["api_key"])
+	secretKey := firstNonEmpty(getenv("XFERS_SECRET_KEY"), profile["secret_key"])
+	env := firstNonEmpty(c.env, getenv("XFERS_ENV"), profile["env"], "sandbox")
+	baseURL := firstNonEmpty(c.baseURL, getenv("XFERS_BASE_URL"), profile["base_url"], envURL[env])
+
+	if apiKey == "" || secretKey == "" {
+		if profileName != "" {
+			return nil, fmt.Errorf("missing api_key or secret_key in profile %q", profileName)
+		}
+		return nil, errors.New("missing api key or secret key, set XFERS_API_KEY and XFERS_SECRET_KEY or a profile")
+	}
+
+	xEnv := xfers.Sandbox
+	switch env {
+	case "sandbox":
+	case "production":
+		xEnv = xfers.Production
+	default:
+		return nil, fmt.Errorf("invalid env %q", env)
+	}
+
+	level := slog.LevelError + 4 // errors are printed by the command
+	if c.debug {
+		level = slog.LevelDebug
+	}
+
+	return xfers.New(xfers.Option{
+		APIKey:    apiKey,
+		SecretKey: secretKey,
+		BaseURL:   baseURL,
+		Env:       xEnv,
+		Logger:    xfers.NewSlogLogger(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level})),
+	}), nil
+}
+
+func configPath() string {
+	if p := os.Getenv("XFERS_CONFIG"); p != "" {
+		return p
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".xfers", "config")
+}
+
+// loadProfile to read the profile section from config file. Missing
+// config file is not an error.
+func loadProfile(name string) (map[string]string, error) {
+	path := configPath()
+	if path == "" {
+		return nil, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	profiles, err := parseProfiles(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	profile, ok := profiles[name]
+	if !ok && name != "default" {
+		return nil, fmt.Errorf("profile %q not found in %s", name, path)
+	}
+
+	return profile, nil
+}
+
+func parseProfiles(r io.Reader) (map[string]map[string]string, error) {
+	profiles := make(map[string]map[string]string)
+	section := "default"
+
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			continue
+		}
+
+		k, v, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: invalid format", n)
+		}
+
+		if profiles[section] == nil {
+			profiles[section] = make(map[string]string)
+		}
+		profiles[section][strings.TrimSpace(k)] = strings.Trim(strings.TrimSpace(v), `"`)
+	}
+
+	return profiles, scanner.Err()
+}
+
+func firstNonEmpty(strs ...string) string {
+	for _, s := range strs {
+		if s != "" {
+			return s
+		}
+	}
+	return ""
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"github.com/rl404/xfers-go"
+)
+
+func createDisbursement(args []string) error {
+	req := xfers.CreateDisbursementRequest{Type: xfers.DisbursementBankTransfer}
+
+	fs, cfg := newFlagSet("disbursement create")
+	enumVar(fs, &req.Type, "type", "disbursement type (default bank_transfer)")
+	fs.StringVar(&req.ReferenceID, "reference-id", "", "unique reference id")
+	fs.TextVar(&req.Amount, "amount", xfers.Amount{}, "amount")
+	enumVar(fs, &req.BankShortCode, "bank", "bank short code")
+	fs.StringVar(&req.BankAccountNo, "account", "", "bank account no")
+	fs.StringVar(&req.BankAccountHolderName, "holder", "", "bank account holder name")
+	fs.StringVar(&req.Description, "description", "", "description")
+	fs.StringVar(&req.IdempotencyKey, "idempotency-key", "", "idempotency key")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	c, err := cfg.client()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := newContext()
+	defer cancel()
+
+	res, _, err := c.CreateDisbursementWithContext(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return cfg.print(res)
+}
+
+func getDisbursement(args []string) error {
+	var id string
+
+	fs, cfg := newFlagSet("disbursement get")
+	fs.StringVar(&id, "id", "", "disbursement id")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	c, err := cfg.client()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := newContext()
+	defer cancel()
+
+	res, _, err := c.GetDisbursementWithContext(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return cfg.print(res)
+}
+
+func listDisbursements(args []string) error {
+	fs, cfg := newFlagSet("disbursement list")
+	pagination, all := paginationFlags(fs)
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	c, err := cfg.client()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := newContext()
+	defer cancel()
+
+	var res []xfers.Disbursement
+	if *all {
+		res, err = collect(c.AllDisbursements(ctx, *pagination))
+	} else {
+		res, _, err = c.GetDisbursementsWithContext(ctx, *pagination)
+	}
+	if err != nil {
+		return err
+	}
+
+	return cfg.print(res)
+}
+
+func simulateDisbursement(args []string) error {
+	var req xfers.SimulateDisbursementRequest
+
+	fs, cfg := newFlagSet("disbursement simulate")
+	fs.StringVar(&req.ID, "id", "", "disbursement id")
+	enumVar(fs, &req.Action, "action", "action: complete or fail")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	c, err := cfg.client()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := newContext()
+	defer cancel()
+
+	res, _, err := c.SimulateDisbursementWithContext(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return cfg.print(res)
+}
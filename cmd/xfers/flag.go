@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"iter"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/rl404/xfers-go"
+)
+
+func enumVar[T ~string](fs *flag.FlagSet, p *T, name, usage string) {
+	fs.Func(name, usage, func(s string) error {
+		*p = T(s)
+		return nil
+	})
+}
+
+// paginationFlags to register pagination flags. The returned bool is
+// the -all flag to fetch all pages.
+func paginationFlags(fs *flag.FlagSet) (*xfers.Pagination, *bool) {
+	var p xfers.Pagination
+	fs.IntVar(&p.Page, "page", 1, "page number")
+	fs.IntVar(&p.PageSize, "page-size", 10, "page size (max 1000)")
+	fs.StringVar(&p.Sort, "sort", "", "sort field, prefix with - for descending")
+	fs.TextVar(&p.CreatedAfter, "created-after", time.Time{}, "created after (RFC3339)")
+	fs.TextVar(&p.CreatedBefore, "created-before", time.Time{}, "created before (RFC3339)")
+	enumVar(fs, &p.Status, "status", "status filter")
+	fs.StringVar(&p.ReferenceID, "reference-id", "", "reference id filter")
+	all := fs.Bool("all", false, "fetch all pages starting from -page")
+	return &p, all
+}
+
+func parse(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected argument %q", fs.Arg(0))
+	}
+	return nil
+}
+
+func newContext() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt)
+}
+
+// collect to get all items from iterator.
+func collect[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	res := []T{}
+	for item, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, item)
+	}
+	return res, nil
+}
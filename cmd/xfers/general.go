@@ -0,0 +1,75 @@
+package main
+
+import (
+	"github.com/rl404/xfers-go"
+)
+
+func balance(args []string) error {
+	fs, cfg := newFlagSet("balance")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	c, err := cfg.client()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := newContext()
+	defer cancel()
+
+	res, _, err := c.GetBalanceWithContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	return cfg.print(res)
+}
+
+func banks(args []string) error {
+	fs, cfg := newFlagSet("banks")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	c, err := cfg.client()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := newContext()
+	defer cancel()
+
+	res, _, err := c.GetBanksWithContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	return cfg.print(res)
+}
+
+func validateAccount(args []string) error {
+	var req xfers.ValidateBankAccountRequest
+
+	fs, cfg := newFlagSet("validate-account")
+	fs.StringVar(&req.AccountNo, "account", "", "bank account no")
+	enumVar(fs, &req.BankShortCode, "bank", "bank short code")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	c, err := cfg.client()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := newContext()
+	defer cancel()
+
+	res, _, err := c.ValidateBankAccountWithContext(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return cfg.print(res)
+}
@@ -0,0 +1,120 @@
+// Command xfers is command line tool for xfers API.
+//
+// Usage:
+//
+//	xfers <command> [subcommand] [flags]
+//
+// Credentials are read from environment variables (XFERS_API_KEY,
+// XFERS_SECRET_KEY) or the default profile in profile file
+// (~/.xfers/config, or XFERS_CONFIG) in that order. The env and base
+// url are read from flags (-env, -base-url), environment variables
+// (XFERS_ENV, XFERS_BASE_URL) or the profile.
+//
+// If a profile is selected with -profile flag or XFERS_PROFILE, all
+// of them are read from the profile and only the flags can override
+// the env and base url.
+//
+// Profile file example:
+//
+//	[default]
+//	api_key = test_xxx
+//	secret_key = abc123
+//	env = sandbox
+//
+//	[prod]
+//	api_key = live_xxx
+//	secret_key = def456
+//	env = production
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+)
+
+const usage = `Usage: xfers <command> [subcommand] [flags]
+
+Commands:
+  balance                              Get account balance
+  banks                                Get disbursement bank list
+  validate-account                     Validate bank account
+  payment create|get|list|simulate     Manage payments
+  disbursement create|get|list|simulate
+                                       Manage disbursements
+  payment-method create|get|payments|simulate
+                                       Manage payment methods
+
+Common flags:
+  -profile string    profile name in config file (default "default")
+  -env string        sandbox or production
+  -base-url string   override API base URL
+  -o string          output format: table, json or csv (default "table")
+  -debug             print request & response log to stderr
+
+Run 'xfers <command> [subcommand] -h' for command flags.
+`
+
+type command func(args []string) error
+
+var commands = map[string]map[string]command{
+	"balance":          {"": balance},
+	"banks":            {"": banks},
+	"validate-account": {"": validateAccount},
+	"payment": {
+		"create":   createPayment,
+		"get":      getPayment,
+		"list":     listPayments,
+		"simulate": simulatePayment,
+	},
+	"disbursement": {
+		"create":   createDisbursement,
+		"get":      getDisbursement,
+		"list":     listDisbursements,
+		"simulate": simulateDisbursement,
+	},
+	"payment-method": {
+		"create":   createPaymentMethod,
+		"get":      getPaymentMethod,
+		"payments": listPaymentMethodPayments,
+		"simulate": simulatePaymentMethod,
+	},
+}
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, "error:", err)
+		}
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		fmt.Fprint(os.Stderr, usage)
+		return nil
+	}
+
+	subs, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprint(os.Stderr, usage)
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+
+	if cmd, ok := subs[""]; ok {
+		return cmd(args[1:])
+	}
+
+	if len(args) < 2 {
+		return fmt.Errorf("missing %s subcommand", args[0])
+	}
+
+	cmd, ok := subs[args[1]]
+	if !ok {
+		return fmt.Errorf("unknown %s subcommand %q", args[0], args[1])
+	}
+
+	return cmd(args[2:])
+}
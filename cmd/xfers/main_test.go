@@ -0,0 +1,102 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rl404/xfers-go/xferstest"
+)
+
+func TestParseProfiles(t *testing.T) {
+	profiles, err := parseProfiles(strings.NewReader(`
+api_key = default-key
+# comment
+[test]
+api_key = "test-key"
+secret_key = test-secret
+; comment
+env = production
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]map[string]string{
+		"default": {"api_key": "default-key"},
+		"test":    {"api_key": "test-key", "secret_key": "test-secret", "env": "production"},
+	}
+	if !reflect.DeepEqual(profiles, want) {
+		t.Fatalf("got %v, want %v", profiles, want)
+	}
+
+	if _, err := parseProfiles(strings.NewReader("[test]\ninvalid")); err == nil {
+		t.Fatal("got nil, want invalid format error")
+	}
+}
+
+func TestRunProfile(t *testing.T) {
+	srv := xferstest.NewServer("api-key", "secret-key")
+	defer srv.Close()
+
+	config := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(config, []byte(`
+api_key = wrong-key
+secret_key = wrong-secret
+base_url = `+srv.URL+`
+
+[test]
+api_key = api-key
+secret_key = secret-key
+base_url = `+srv.URL+`
+
+[partial]
+api_key = api-key
+`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("XFERS_CONFIG", config)
+	t.Setenv("XFERS_PROFILE", "")
+	t.Setenv("XFERS_API_KEY", "env-key")
+	t.Setenv("XFERS_SECRET_KEY", "env-secret")
+	t.Setenv("XFERS_ENV", "")
+	t.Setenv("XFERS_BASE_URL", "")
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "selected profile ignores env vars", args: []string{"balance", "-profile", "test", "-o", "json"}},
+		{name: "env vars over default profile", args: []string{"balance", "-o", "json"}, wantErr: "invalid api key"},
+		{name: "missing secret in selected profile", args: []string{"balance", "-profile", "partial"}, wantErr: `missing api_key or secret_key in profile "partial"`},
+		{name: "unknown profile", args: []string{"balance", "-profile", "unknown"}, wantErr: `profile "unknown" not found`},
+		{name: "unexpected argument", args: []string{"balance", "-profile", "test", "extra"}, wantErr: `unexpected argument "extra"`},
+		{name: "unknown command", args: []string{"unknown"}, wantErr: `unknown command "unknown"`},
+		{name: "invalid output", args: []string{"balance", "-profile", "test", "-o", "xml"}, wantErr: `invalid output format "xml"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := run(tt.args)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("got %v, want %s", err, tt.wantErr)
+			}
+			if errors.Is(err, flag.ErrHelp) {
+				t.Fatalf("got %v, want error with message", err)
+			}
+		})
+	}
+}
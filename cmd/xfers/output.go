@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+type printer func(w io.Writer, data interface{}) error
+
+var printers = map[string]printer{
+	"table": printTable,
+	"json":  printJSON,
+	"csv":   printCSV,
+}
+
+// print to print the data to stdout. Data is either a struct pointer
+// or a slice of struct.
+func (c *config) print(data interface{}) error {
+	return printers[c.output](os.Stdout, data)
+}
+
+func printJSON(w io.Writer, data interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(data)
+}
+
+func printTable(w io.Writer, data interface{}) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	header, rows, list := toRows(data)
+	if list {
+		fmt.Fprintln(tw, strings.ToUpper(strings.Join(header, "\t")))
+		for _, r := range rows {
+			fmt.Fprintln(tw, strings.Join(r, "\t"))
+		}
+		return tw.Flush()
+	}
+
+	// Single item is printed vertically.
+	for i, h := range header {
+		fmt.Fprintf(tw, "%s\t%s\n", h, rows[0][i])
+	}
+	return tw.Flush()
+}
+
+func printCSV(w io.Writer, data interface{}) error {
+	cw := csv.NewWriter(w)
+
+	header, rows, _ := toRows(data)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// toRows to convert struct or slice of struct to header and rows
+// using the exported field names.
+func toRows(data interface{}) (header []string, rows [][]string, list bool) {
+	v := reflect.Indirect(reflect.ValueOf(data))
+
+	var items []reflect.Value
+	if v.Kind() == reflect.Slice {
+		list = true
+		for i := 0; i < v.Len(); i++ {
+			items = append(items, reflect.Indirect(v.Index(i)))
+		}
+	} else {
+		items = append(items, v)
+	}
+
+	t := v.Type()
+	if list {
+		t = t.Elem()
+		if t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() {
+			header = append(header, t.Field(i).Name)
+		}
+	}
+
+	for _, item := range items {
+		var row []string
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).IsExported() {
+				row = append(row, format(item.Field(i)))
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return header, rows, list
+}
+
+func format(v reflect.Value) string {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+
+	switch x := v.Interface().(type) {
+	case time.Time:
+		if x.IsZero() {
+			return ""
+		}
+		return x.Format(time.RFC3339)
+	case fmt.Stringer:
+		return x.String()
+	}
+
+	if v.Kind() == reflect.Struct {
+		return fmt.Sprintf("%+v", v.Interface())
+	}
+
+	return fmt.Sprint(v.Interface())
+}
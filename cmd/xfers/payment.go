@@ -0,0 +1,129 @@
+package main
+
+import (
+	"time"
+
+	"github.com/rl404/xfers-go"
+)
+
+func createPayment(args []string) error {
+	var req xfers.CreatePaymentRequest
+	var expiresIn time.Duration
+
+	fs, cfg := newFlagSet("payment create")
+	enumVar(fs, &req.PaymentMethodType, "type", "payment type: virtual_bank_account, qris, retail_outlet or e-wallet")
+	fs.TextVar(&req.Amount, "amount", xfers.Amount{}, "amount")
+	fs.StringVar(&req.ReferenceID, "reference-id", "", "unique reference id")
+	fs.TextVar(&req.ExpiredAt, "expired-at", time.Time{}, "expiration time (RFC3339), overrides -expires-in")
+	fs.DurationVar(&expiresIn, "expires-in", 24*time.Hour, "expiration duration from now")
+	fs.StringVar(&req.Description, "description", "", "description")
+	fs.StringVar(&req.DisplayName, "display-name", "", "display name")
+	enumVar(fs, &req.RetailOutletName, "retail-outlet", "retail outlet name (retail_outlet)")
+	enumVar(fs, &req.BankShortCode, "bank", "bank short code (virtual_bank_account)")
+	fs.StringVar(&req.SuffixNo, "suffix-no", "", "account no suffix (virtual_bank_account)")
+	enumVar(fs, &req.ProvideCode, "provider", "e-wallet provider code (e-wallet)")
+	fs.StringVar(&req.AfterSettlementReturnURL, "return-url", "", "after settlement return url (e-wallet)")
+	fs.StringVar(&req.IdempotencyKey, "idempotency-key", "", "idempotency key")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	if req.ExpiredAt.IsZero() {
+		req.ExpiredAt = time.Now().Add(expiresIn)
+	}
+
+	c, err := cfg.client()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := newContext()
+	defer cancel()
+
+	res, _, err := c.CreatePaymentWithContext(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return cfg.print(res)
+}
+
+func getPayment(args []string) error {
+	var id string
+
+	fs, cfg := newFlagSet("payment get")
+	fs.StringVar(&id, "id", "", "payment id")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	c, err := cfg.client()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := newContext()
+	defer cancel()
+
+	res, _, err := c.GetPaymentWithContext(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return cfg.print(res)
+}
+
+func listPayments(args []string) error {
+	fs, cfg := newFlagSet("payment list")
+	pagination, all := paginationFlags(fs)
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	c, err := cfg.client()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := newContext()
+	defer cancel()
+
+	var res []xfers.Payment
+	if *all {
+		res, err = collect(c.AllPayments(ctx, *pagination))
+	} else {
+		res, _, err = c.GetPaymentsWithContext(ctx, *pagination)
+	}
+	if err != nil {
+		return err
+	}
+
+	return cfg.print(res)
+}
+
+func simulatePayment(args []string) error {
+	var req xfers.SimulatePaymentRequest
+
+	fs, cfg := newFlagSet("payment simulate")
+	fs.StringVar(&req.ID, "id", "", "payment id")
+	enumVar(fs, &req.Action, "action", "action: receive_payment, settle or cancel")
+	fs.TextVar(&req.Amount, "amount", xfers.Amount{}, "amount")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	c, err := cfg.client()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := newContext()
+	defer cancel()
+
+	res, _, err := c.SimulatePaymentWithContext(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return cfg.print(res)
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"github.com/rl404/xfers-go"
+)
+
+func createPaymentMethod(args []string) error {
+	var req xfers.CreatePaymentMethodRequest
+
+	fs, cfg := newFlagSet("payment-method create")
+	enumVar(fs, &req.Type, "type", "payment method type: virtual_bank_account or qris")
+	fs.StringVar(&req.ReferenceID, "reference-id", "", "unique reference id")
+	fs.StringVar(&req.DisplayName, "display-name", "", "display name")
+	enumVar(fs, &req.BankShortCode, "bank", "bank short code (virtual_bank_account)")
+	fs.StringVar(&req.SuffixNo, "suffix-no", "", "account no suffix (virtual_bank_account)")
+	fs.StringVar(&req.IdempotencyKey, "idempotency-key", "", "idempotency key")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	c, err := cfg.client()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := newContext()
+	defer cancel()
+
+	res, _, err := c.CreatePaymentMethodWithContext(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return cfg.print(res)
+}
+
+func getPaymentMethod(args []string) error {
+	var req xfers.GetPaymentMethodRequest
+
+	fs, cfg := newFlagSet("payment-method get")
+	fs.StringVar(&req.ID, "id", "", "payment method id")
+	enumVar(fs, &req.Type, "type", "payment method type: virtual_bank_account or qris")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	c, err := cfg.client()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := newContext()
+	defer cancel()
+
+	res, _, err := c.GetPaymentMethodWithContext(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return cfg.print(res)
+}
+
+func listPaymentMethodPayments(args []string) error {
+	var req xfers.GetPaymentMethodRequest
+
+	fs, cfg := newFlagSet("payment-method payments")
+	fs.StringVar(&req.ID, "id", "", "payment method id")
+	enumVar(fs, &req.Type, "type", "payment method type: virtual_bank_account or qris")
+	pagination, all := paginationFlags(fs)
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	c, err := cfg.client()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := newContext()
+	defer cancel()
+
+	var res []xfers.Payment
+	if *all {
+		res, err = collect(c.AllPaymentMethodPayments(ctx, req, *pagination))
+	} else {
+		res, _, err = c.GetPaymentMethodsWithContext(ctx, req, *pagination)
+	}
+	if err != nil {
+		return err
+	}
+
+	return cfg.print(res)
+}
+
+func simulatePaymentMethod(args []string) error {
+	var req xfers.SimulatePaymentMethodRequest
+
+	fs, cfg := newFlagSet("payment-method simulate")
+	fs.StringVar(&req.ID, "id", "", "payment method id")
+	enumVar(fs, &req.Type, "type", "payment method type: virtual_bank_account or qris")
+	enumVar(fs, &req.Action, "action", "action: receive_payment")
+	fs.TextVar(&req.Amount, "amount", xfers.Amount{}, "amount")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	c, err := cfg.client()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := newContext()
+	defer cancel()
+
+	res, _, err := c.SimulatePaymentWithMethodContext(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return cfg.print(res)
+}
@@ -229,3 +229,90 @@ const (
 	BankBJBSyariah                   BankCode = "BJB_SYR"
 	BankBNISyariah                   BankCode = "BNI_SYR"
 )
+
+// Operation is name of client operation.
+type Operation string
+
+// Available options for Operation.
+const (
+	OpGetBalance            Operation = "GetBalance"
+	OpGetBanks              Operation = "GetBanks"
+	OpValidateBankAccount   Operation = "ValidateBankAccount"
+	OpCreatePayment         Operation = "CreatePayment"
+	OpGetPayment            Operation = "GetPayment"
+	OpGetPayments           Operation = "GetPayments"
+	OpSimulatePayment       Operation = "SimulatePayment"
+	OpCreatePaymentMethod   Operation = "CreatePaymentMethod"
+	OpGetPaymentMethod      Operation = "GetPaymentMethod"
+	OpGetPaymentMethods     Operation = "GetPaymentMethods"
+	OpSimulatePaymentMethod Operation = "SimulatePaymentMethod"
+	OpCreateDisbursement    Operation = "CreateDisbursement"
+	OpGetDisbursement       Operation = "GetDisbursement"
+	OpGetDisbursements      Operation = "GetDisbursements"
+	OpSimulateDisbursement  Operation = "SimulateDisbursement"
+)
+
+// VerifyMode is type for bank account verification mode.
+type VerifyMode string
+
+// Available options for VerifyMode.
+const (
+	VerifyReject VerifyMode = "reject"
+	VerifyFlag   VerifyMode = "flag"
+)
+
+// PolicyRule is type for spending policy rule.
+type PolicyRule string
+
+// Available options for PolicyRule.
+const (
+	PolicyMaxAmount          PolicyRule = "max_amount"
+	PolicyHourlyTotal        PolicyRule = "hourly_total"
+	PolicyDailyTotal         PolicyRule = "daily_total"
+	PolicyAccountHourlyTotal PolicyRule = "account_hourly_total"
+	PolicyAccountDailyTotal  PolicyRule = "account_daily_total"
+	PolicyAccountHourlyCount PolicyRule = "account_hourly_count"
+	PolicyAccountDailyCount  PolicyRule = "account_daily_count"
+	PolicyBankNotAllowed     PolicyRule = "bank_not_allowed"
+	PolicyBankDenied         PolicyRule = "bank_denied"
+)
+
+// ApprovalStatus is type for disbursement approval status.
+type ApprovalStatus string
+
+// Available options for ApprovalStatus.
+const (
+	ApprovalPending  ApprovalStatus = "pending"
+	ApprovalRejected ApprovalStatus = "rejected"
+	ApprovalApproved ApprovalStatus = "approved" // sending
+	ApprovalSent     ApprovalStatus = "sent"
+	ApprovalFailed   ApprovalStatus = "failed"
+)
+
+// ApprovalAction is type for disbursement approval audit action.
+type ApprovalAction string
+
+// Available options for ApprovalAction.
+const (
+	ApprovalActionSubmit     ApprovalAction = "submit"
+	ApprovalActionApprove    ApprovalAction = "approve"
+	ApprovalActionReject     ApprovalAction = "reject"
+	ApprovalActionSend       ApprovalAction = "send"
+	ApprovalActionSendFailed ApprovalAction = "send_failed"
+)
+
+// BulkStatus is type for bulk disbursement item result status.
+type BulkStatus string
+
+// Available options for BulkStatus.
+const (
+	BulkPending             BulkStatus = "pending" // checkpoint only
+	BulkCreated             BulkStatus = "created"
+	BulkDuplicate           BulkStatus = "duplicate"
+	BulkValidationError     BulkStatus = "validation_error"
+	BulkInsufficientBalance BulkStatus = "insufficient_balance"
+	BulkPolicyViolation     BulkStatus = "policy_violation"
+	BulkAPIError            BulkStatus = "api_error"
+	BulkError               BulkStatus = "error"
+	BulkCancelled           BulkStatus = "cancelled"
+)
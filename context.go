@@ -0,0 +1,48 @@
+package xfers
+
+import "context"
+
+type referenceIDKey struct{}
+
+// withReferenceID to put the request's reference id in context
+// so it can be logged by the requester.
+func withReferenceID(ctx context.Context, referenceID string) context.Context {
+	return context.WithValue(ctx, referenceIDKey{}, referenceID)
+}
+
+func referenceIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(referenceIDKey{}).(string)
+	return id
+}
+
+type operationKey struct{}
+
+func withOperation(ctx context.Context, op Operation) context.Context {
+	return context.WithValue(ctx, operationKey{}, op)
+}
+
+// OperationFromContext to get the client operation name of the
+// request. Useful for custom Requester and Middleware.
+func OperationFromContext(ctx context.Context) Operation {
+	op, _ := ctx.Value(operationKey{}).(Operation)
+	return op
+}
+
+type attemptKey struct{}
+
+func withAttempt(ctx context.Context, attempt int) context.Context {
+	return context.WithValue(ctx, attemptKey{}, attempt)
+}
+
+// AttemptFromContext to get the request attempt number set by
+// RetryMiddleware. Returns 1 if the request is not retried.
+func AttemptFromContext(ctx context.Context) int {
+	return attemptFromContext(ctx)
+}
+
+func attemptFromContext(ctx context.Context) int {
+	if attempt, ok := ctx.Value(attemptKey{}).(int); ok {
+		return attempt
+	}
+	return 1
+}
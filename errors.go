@@ -3,13 +3,31 @@ package xfers
 import (
 	"errors"
 	"fmt"
+	"net/http"
+	"strings"
 )
 
 var (
 	// ErrInternal is general internal error.
 	ErrInternal = errors.New("internal error")
+	// ErrEncodeRequest is error when the request can't be encoded.
+	// The request is never sent so it is not retried.
+	ErrEncodeRequest = errors.New("failed to encode request")
 	// ErrSandboxOnly is error when calling sandbox feature only in prod env.
 	ErrSandboxOnly = errors.New("sandbox only")
+	// ErrInsufficientBalance is error when available balance is not
+	// enough for the disbursement. See InsufficientBalanceError.
+	ErrInsufficientBalance = errors.New("insufficient balance")
+	// ErrPolicyViolation is error when disbursement violates the
+	// spending policy. See PolicyError.
+	ErrPolicyViolation = errors.New("spending policy violation")
+	// ErrInvalidEnum is error when decoding unknown enum value
+	// of the public models.
+	ErrInvalidEnum = errors.New("invalid enum value")
+	// ErrReferenceMismatch is error when the existing resource with
+	// the same reference id is different from the create request.
+	// See ReferenceMismatchError.
+	ErrReferenceMismatch = errors.New("reference id mismatch")
 )
 
 func errRequiredField(str string) error {
@@ -39,3 +57,114 @@ func errGTEField(str, value string) error {
 func errMaxField(str, value string) error {
 	return fmt.Errorf("field %s max value is %s", str, value)
 }
+
+// ErrorObject is a single JSON:API error object returned by xfers.
+type ErrorObject struct {
+	Code   string       `json:"code"`
+	Title  string       `json:"title"`
+	Detail string       `json:"detail"`
+	Source *ErrorSource `json:"source,omitempty"`
+}
+
+// ErrorSource is the part of request causing the error.
+type ErrorSource struct {
+	Pointer   string `json:"pointer,omitempty"`
+	Parameter string `json:"parameter,omitempty"`
+}
+
+// APIError is error returned by xfers API with non-2xx status code.
+//
+// Use errors.As to get the full error detail.
+type APIError struct {
+	StatusCode int
+	RequestID  string
+	Header     http.Header
+	Errors     []ErrorObject
+	Body       []byte
+}
+
+// Error to get the error message. Only the first error
+// object is used to keep the message short.
+func (e *APIError) Error() string {
+	if len(e.Errors) > 0 {
+		if e.Errors[0].Detail != "" {
+			return e.Errors[0].Detail
+		}
+		if e.Errors[0].Title != "" {
+			return e.Errors[0].Title
+		}
+	}
+	return http.StatusText(e.StatusCode)
+}
+
+// HasCode to check if any of the error objects has the code.
+// The comparison is case insensitive.
+func (e *APIError) HasCode(code string) bool {
+	for _, obj := range e.Errors {
+		if strings.EqualFold(obj.Code, code) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *APIError) contains(keywords ...string) bool {
+	for _, obj := range e.Errors {
+		str := strings.ToLower(obj.Code + " " + obj.Title + " " + obj.Detail)
+		for _, k := range keywords {
+			if strings.Contains(str, k) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsNotFound to check if the error is caused by resource not found.
+// Only status 404 and not_found error code are matched, error detail
+// like "bank account not found" in 422 response is not.
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.StatusCode == http.StatusNotFound || apiErr.HasCode("not_found")
+}
+
+// IsDuplicateReference to check if the error is caused by
+// duplicate reference id.
+func IsDuplicateReference(err error) bool {
+	if errors.Is(err, ErrReferenceMismatch) {
+		return true
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.StatusCode == http.StatusConflict || apiErr.contains("duplicate", "already been taken", "already exists")
+}
+
+// IsInsufficientBalance to check if the error is caused by
+// insufficient account balance.
+func IsInsufficientBalance(err error) bool {
+	if errors.Is(err, ErrInsufficientBalance) {
+		return true
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.contains("insufficient")
+}
+
+// IsUnauthorized to check if the error is caused by invalid
+// api key or secret key.
+func IsUnauthorized(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden
+}
@@ -0,0 +1,33 @@
+package xfers_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/rl404/xfers-go"
+)
+
+func TestIsNotFound(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "404", err: &xfers.APIError{StatusCode: http.StatusNotFound}, want: true},
+		{name: "not_found code", err: &xfers.APIError{StatusCode: http.StatusBadRequest, Errors: []xfers.ErrorObject{{Code: "NOT_FOUND"}}}, want: true},
+		{name: "wrapped 404", err: fmt.Errorf("get: %w", &xfers.APIError{StatusCode: http.StatusNotFound}), want: true},
+		{name: "not found detail", err: &xfers.APIError{StatusCode: http.StatusUnprocessableEntity, Errors: []xfers.ErrorObject{{Code: "invalid_parameter", Detail: "bank account not found"}}}},
+		{name: "not_found in title", err: &xfers.APIError{StatusCode: http.StatusUnprocessableEntity, Errors: []xfers.ErrorObject{{Title: "account_not_found"}}}},
+		{name: "non api error", err: xfers.ErrInternal},
+		{name: "nil"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := xfers.IsNotFound(tt.err); got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
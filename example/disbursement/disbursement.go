@@ -18,7 +18,7 @@ func main() {
 		BankAccountHolderName: "Name",
 		BankAccountNo:         "123",
 		BankShortCode:         xfers.BankBCA,
-		Amount:                10000,
+		Amount:                xfers.NewAmount(10000),
 		Description:           "desc",
 	})
 	if err != nil {
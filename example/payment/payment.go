@@ -15,7 +15,7 @@ func main() {
 
 	payment, code, err := x.CreatePayment(xfers.CreatePaymentRequest{
 		PaymentMethodType: xfers.PaymentVA,
-		Amount:            20000,
+		Amount:            xfers.NewAmount(20000),
 		ReferenceID:       "uuid-payment-7",
 		ExpiredAt:         time.Now().Add(2 * time.Hour),
 		Description:       "description",
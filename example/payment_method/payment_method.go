@@ -61,7 +61,7 @@ func main() {
 		ID:     payment.ID,
 		Type:   xfers.PaymentVA,
 		Action: xfers.ActionReceivePayment,
-		Amount: 10000,
+		Amount: xfers.NewAmount(10000),
 	})
 	if err != nil {
 		log.Println(code, err)
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"io/ioutil"
+	"log/slog"
 	"net/http"
 	"time"
 )
@@ -16,14 +17,20 @@ type Requester interface {
 }
 
 type requester struct {
-	client *http.Client
-	logger Logger
+	client   *http.Client
+	logger   Logger
+	redactor *redactor
 }
 
-func defaultRequester(client *http.Client, logger Logger) *requester {
+func defaultRequester(client *http.Client, logger Logger, redactFields []string) *requester {
+	if redactFields == nil {
+		redactFields = defaultRedactFields
+	}
+
 	return &requester{
-		client: client,
-		logger: logger,
+		client:   client,
+		logger:   logger,
+		redactor: newRedactor(redactFields),
 	}
 }
 
@@ -34,17 +41,17 @@ func (r *requester) Call(ctx context.Context, method, url, apiKey, secretKey str
 	reqBody, err := json.Marshal(request)
 	if err != nil {
 		r.logger.Error(err.Error())
-		return http.StatusInternalServerError, ErrInternal
+		return http.StatusInternalServerError, ErrEncodeRequest
 	}
 
 	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewBuffer(reqBody))
 	if err != nil {
 		r.logger.Error(err.Error())
-		return http.StatusInternalServerError, ErrInternal
+		return http.StatusInternalServerError, ErrEncodeRequest
 	}
 
 	if header != nil {
-		req.Header = header
+		req.Header = header.Clone()
 	}
 
 	req.SetBasicAuth(apiKey, secretKey)
@@ -54,53 +61,103 @@ func (r *requester) Call(ctx context.Context, method, url, apiKey, secretKey str
 	r.logger.Debug("%s %s", method, url)
 	r.logRequestHeader(req.Header)
 	r.logRequestBody(reqBody)
-	defer func() { r.logger.Info("%s %s [%s]", method, url, time.Since(now)) }()
 
-	return r.doRequest(req, response)
-}
+	code, respHeader, respBody, err := r.doRequest(req, response)
+	latency := time.Since(now)
+	r.logResult(ctx, req, code, respHeader, latency, err)
+	captureResponse(ctx, code, respHeader, respBody, latency, err)
 
-type errReponse struct {
-	Errors []struct {
-		Code   string `json:"code"`
-		Title  string `json:"title"`
-		Detail string `json:"detail"`
-	} `json:"errors"`
+	return code, err
 }
 
-func (r *requester) doRequest(req *http.Request, response interface{}) (int, error) {
+func (r *requester) doRequest(req *http.Request, response interface{}) (int, http.Header, []byte, error) {
 	resp, err := r.client.Do(req)
 	if err != nil {
 		r.logger.Error(err.Error())
-		return http.StatusInternalServerError, ErrInternal
+		return http.StatusInternalServerError, nil, nil, ErrInternal
 	}
 	defer resp.Body.Close()
 
 	respBody, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
 		r.logger.Error(err.Error())
-		return http.StatusInternalServerError, ErrInternal
+		return http.StatusInternalServerError, resp.Header, nil, ErrInternal
 	}
 
 	r.logResponseBody(resp.StatusCode, respBody)
 
 	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		var errResp errReponse
-		if err := json.Unmarshal(respBody, &errResp); err != nil {
-			r.logger.Error(err.Error())
-			return http.StatusInternalServerError, ErrInternal
+		apiErr := &APIError{
+			StatusCode: resp.StatusCode,
+			RequestID:  requestID(resp.Header),
+			Header:     resp.Header,
+			Body:       respBody,
 		}
-		if len(errResp.Errors) > 0 {
-			return resp.StatusCode, errors.New(errResp.Errors[0].Detail)
+		var doc Document
+		if err := json.Unmarshal(respBody, &doc); err != nil {
+			r.logger.Error(err.Error())
+			return resp.StatusCode, resp.Header, respBody, apiErr
 		}
-		return resp.StatusCode, errors.New(http.StatusText(resp.StatusCode))
+		apiErr.Errors = doc.Errors
+		return resp.StatusCode, resp.Header, respBody, apiErr
 	}
 
 	if err := json.Unmarshal(respBody, &response); err != nil {
 		r.logger.Error(err.Error())
-		return http.StatusInternalServerError, ErrInternal
+		if errors.Is(err, ErrInvalidAmount) {
+			return http.StatusInternalServerError, resp.Header, respBody, err
+		}
+		return http.StatusInternalServerError, resp.Header, respBody, ErrInternal
 	}
 
-	return resp.StatusCode, nil
+	return resp.StatusCode, resp.Header, respBody, nil
+}
+
+func requestID(header http.Header) string {
+	for _, k := range []string{"X-Request-Id", "Request-Id", "X-Amzn-Requestid"} {
+		if id := header.Get(k); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+func (r *requester) logResult(ctx context.Context, req *http.Request, code int, header http.Header, latency time.Duration, err error) {
+	attempt := attemptFromContext(ctx)
+
+	sl, ok := r.logger.(StructuredLogger)
+	if !ok {
+		r.logger.Info("%s %s [%s] (attempt %d)", req.Method, req.URL.String(), latency, attempt)
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.Path),
+		slog.Int("status", code),
+		slog.Duration("latency", latency),
+		slog.Int("attempt", attempt),
+	}
+
+	if op := OperationFromContext(ctx); op != "" {
+		attrs = append(attrs, slog.String("operation", string(op)))
+	}
+
+	if id := referenceIDFromContext(ctx); id != "" {
+		attrs = append(attrs, slog.String("reference_id", id))
+	}
+
+	if id := requestID(header); id != "" {
+		attrs = append(attrs, slog.String("request_id", id))
+	}
+
+	level := LogInfo
+	if err != nil {
+		level = LogError
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+
+	sl.Log(ctx, level, "xfers request", attrs...)
 }
 
 func (r *requester) logRequestHeader(header http.Header) {
@@ -108,7 +165,7 @@ func (r *requester) logRequestHeader(header http.Header) {
 		return
 	}
 
-	for k, h := range header {
+	for k, h := range r.redactor.header(header) {
 		for _, v := range h {
 			r.logger.Debug("header: %s: %s", k, v)
 		}
@@ -120,6 +177,8 @@ func (r *requester) logRequestBody(request []byte) {
 		return
 	}
 
+	request = r.redactor.body(request)
+
 	var out bytes.Buffer
 	if err := json.Indent(&out, request, "", "  "); err != nil {
 		r.logger.Error(err.Error())
@@ -135,6 +194,8 @@ func (r *requester) logResponseBody(code int, response []byte) {
 		return
 	}
 
+	response = r.redactor.body(response)
+
 	var out bytes.Buffer
 	if err := json.Indent(&out, response, "", "  "); err != nil {
 		r.logger.Error(err.Error())
@@ -0,0 +1,196 @@
+package xfers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// IdempotencyPolicy is config for recovering create requests
+// (payment, disbursement and payment method) from ambiguous
+// failures such as timeout, network error and 5xx response.
+//
+// When a create request fails ambiguously, the resource will be
+// looked up by its reference id first. If found and it matches the
+// request, the existing resource is returned. Otherwise, the create
+// request is re-issued with the same reference id and idempotency key.
+//
+// Payment method can't be looked up, so its create request is only
+// re-issued if the request has an idempotency key.
+//
+// Custom Requester should wrap transport errors with ErrInternal so
+// they are recognized as ambiguous.
+type IdempotencyPolicy struct {
+	// Max create attempts including the first one.
+	// Less than 2 means disabled.
+	MaxAttempts int
+	// Waiting duration before looking up the resource.
+	LookupDelay time.Duration
+}
+
+func (p IdempotencyPolicy) enabled() bool {
+	return p.MaxAttempts > 1
+}
+
+// isAmbiguous to check if the failed request may or may not
+// have been processed by xfers. Only transport errors (ErrInternal)
+// and 5xx responses are ambiguous. Errors before the request is sent
+// such as validation, circuit breaker, rate limit and request
+// encoding are not.
+func isAmbiguous(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= http.StatusInternalServerError
+	}
+	return errors.Is(err, ErrInternal)
+}
+
+// errExisting is used to release client side reservations when
+// the resource was created by an earlier call.
+var errExisting = errors.New("resource is created by earlier call")
+
+func idempotencyHeader(key string) http.Header {
+	if key == "" {
+		return nil
+	}
+	return http.Header{"Idempotency-Key": []string{key}}
+}
+
+// ReferenceMismatchError is returned when the existing resource
+// found by reference id is different from the create request. It
+// matches ErrReferenceMismatch with errors.Is and is also a duplicate
+// reference error.
+type ReferenceMismatchError struct {
+	ReferenceID string
+	// Json field name of the first different value.
+	Field string
+}
+
+// Error to get error message.
+func (e *ReferenceMismatchError) Error() string {
+	return fmt.Sprintf("reference id %s is already used with different %s", e.ReferenceID, e.Field)
+}
+
+// Is to match ErrReferenceMismatch.
+func (e *ReferenceMismatchError) Is(target error) bool {
+	return target == ErrReferenceMismatch
+}
+
+// createIdempotent to call create and recover from ambiguous
+// failure by looking up the resource before re-issuing create.
+// Lookup returns nil if the resource is not found. Nil lookup means
+// the resource can't be looked up so create is re-issued directly.
+// The found resource is only returned if match returns nil.
+//
+// Existed is true if the found resource was created by an earlier
+// call, that is every failed attempt of this call was rejected as
+// duplicate reference. The caller should not count it as created.
+func createIdempotent[T any](ctx context.Context, policy IdempotencyPolicy, create func() (*T, int, error), lookup func() (*T, error), match func(*T) error) (res *T, code int, existed bool, err error) {
+	res, code, err = create()
+	if err == nil || !policy.enabled() {
+		return res, code, false, err
+	}
+
+	existed = true
+
+	for attempt := 1; ; attempt++ {
+		duplicate := IsDuplicateReference(err)
+		if !duplicate && !isAmbiguous(err) {
+			return res, code, false, err
+		}
+
+		if !duplicate {
+			existed = false
+		}
+
+		if ctx.Err() != nil {
+			return res, code, false, err
+		}
+
+		if lookup != nil {
+			if policy.LookupDelay > 0 && sleep(ctx, policy.LookupDelay) != nil {
+				return res, code, false, err
+			}
+
+			found, lErr := lookup()
+			if lErr != nil {
+				return res, code, false, err
+			}
+
+			if found != nil {
+				if mErr := match(found); mErr != nil {
+					return nil, http.StatusConflict, false, mErr
+				}
+				return found, http.StatusOK, existed, nil
+			}
+		}
+
+		if duplicate || attempt >= policy.MaxAttempts {
+			return res, code, false, err
+		}
+
+		res, code, err = create()
+		if err == nil {
+			return res, code, false, nil
+		}
+	}
+}
+
+func (c *Client) lookupDisbursement(ctx context.Context, referenceID string) (*Disbursement, error) {
+	disbursements, _, err := c.GetDisbursementsWithContext(ctx, Pagination{ReferenceID: referenceID})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, d := range disbursements {
+		if d.ReferenceID == referenceID {
+			return &d, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// matchDisbursement to check if the existing disbursement is
+// created from the same request.
+func matchDisbursement(request CreateDisbursementRequest, d *Disbursement) error {
+	switch {
+	case d.Amount.Cmp(request.Amount) != 0:
+		return &ReferenceMismatchError{ReferenceID: request.ReferenceID, Field: "amount"}
+	case !strings.EqualFold(string(d.BankShortCode), string(request.BankShortCode)):
+		return &ReferenceMismatchError{ReferenceID: request.ReferenceID, Field: "bankShortCode"}
+	case d.BankAccountNo != request.BankAccountNo:
+		return &ReferenceMismatchError{ReferenceID: request.ReferenceID, Field: "bankAccountNo"}
+	}
+	return nil
+}
+
+func (c *Client) lookupPayment(ctx context.Context, referenceID string) (*Payment, error) {
+	payments, _, err := c.GetPaymentsWithContext(ctx, Pagination{ReferenceID: referenceID})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range payments {
+		if p.ReferenceID == referenceID {
+			return &p, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// matchPayment to check if the existing payment is created
+// from the same request.
+func matchPayment(request CreatePaymentRequest, p *Payment) error {
+	switch {
+	case p.Type != "" && p.Type != request.PaymentMethodType:
+		return &ReferenceMismatchError{ReferenceID: request.ReferenceID, Field: "paymentMethodType"}
+	case p.Amount.Cmp(request.Amount) != 0:
+		return &ReferenceMismatchError{ReferenceID: request.ReferenceID, Field: "amount"}
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+package xfers_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rl404/xfers-go"
+	"github.com/rl404/xfers-go/xferstest"
+)
+
+var errTimeout = fmt.Errorf("%w: timeout", xfers.ErrInternal)
+
+// dropResponse to create middleware passing the first n create
+// requests to the server but returning ambiguous error.
+func dropResponse(n int32, posts *int32) xfers.Middleware {
+	return func(next xfers.Requester) xfers.Requester {
+		return xfers.RequesterFunc(func(ctx context.Context, method, url, apiKey, secretKey string, header http.Header, request interface{}, response interface{}) (int, error) {
+			if method != http.MethodPost || strings.HasSuffix(url, "/tasks") {
+				return next.Call(ctx, method, url, apiKey, secretKey, header, request, response)
+			}
+
+			count := atomic.AddInt32(posts, 1)
+			code, err := next.Call(ctx, method, url, apiKey, secretKey, header, request, response)
+			if count <= n {
+				return 0, errTimeout
+			}
+			return code, err
+		})
+	}
+}
+
+// failPost to create middleware failing every create request with
+// the error without sending it.
+func failPost(err error) xfers.Middleware {
+	return func(next xfers.Requester) xfers.Requester {
+		return xfers.RequesterFunc(func(ctx context.Context, method, url, apiKey, secretKey string, header http.Header, request interface{}, response interface{}) (int, error) {
+			if method != http.MethodPost || strings.HasSuffix(url, "/tasks") {
+				return next.Call(ctx, method, url, apiKey, secretKey, header, request, response)
+			}
+			return http.StatusInternalServerError, err
+		})
+	}
+}
+
+// seedClient to create client without any policy to the same server.
+func seedClient(srv *xferstest.Server) *xfers.Client {
+	return xfers.New(xfers.Option{
+		APIKey:    "api-key",
+		SecretKey: "secret-key",
+		BaseURL:   srv.URL,
+		Env:       xfers.Sandbox,
+	})
+}
+
+func TestCreateIdempotent(t *testing.T) {
+	var posts int32
+	client, _ := newTestClient(t, xfers.Option{
+		Idempotency: xfers.IdempotencyPolicy{MaxAttempts: 3},
+		Middlewares: []xfers.Middleware{dropResponse(1, &posts)},
+	})
+
+	d, _, err := client.CreateDisbursement(disbursementRequest("r1", 100000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.ReferenceID != "r1" || d.Amount.Cmp(xfers.NewAmount(100000)) != 0 {
+		t.Fatalf("got %+v", d)
+	}
+	if posts != 1 {
+		t.Fatalf("got %d create requests, want 1", posts)
+	}
+
+	disbursements, _, err := client.GetDisbursements(xfers.Pagination{ReferenceID: "r1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(disbursements) != 1 {
+		t.Fatalf("got %d disbursements, want 1", len(disbursements))
+	}
+}
+
+func TestCreateIdempotentMismatch(t *testing.T) {
+	client, _ := newTestClient(t, xfers.Option{
+		Idempotency: xfers.IdempotencyPolicy{MaxAttempts: 3},
+	})
+
+	if _, _, err := client.CreateDisbursement(disbursementRequest("r1", 100000)); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*xfers.CreateDisbursementRequest)
+		field  string
+	}{
+		{name: "same", modify: func(*xfers.CreateDisbursementRequest) {}},
+		{name: "amount", modify: func(r *xfers.CreateDisbursementRequest) { r.Amount = xfers.NewAmount(5000) }, field: "amount"},
+		{name: "bank", modify: func(r *xfers.CreateDisbursementRequest) { r.BankShortCode = xfers.BankBNI }, field: "bankShortCode"},
+		{name: "account", modify: func(r *xfers.CreateDisbursementRequest) { r.BankAccountNo = "999999" }, field: "bankAccountNo"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := disbursementRequest("r1", 100000)
+			tt.modify(&request)
+
+			d, code, err := client.CreateDisbursement(request)
+			if tt.field == "" {
+				if err != nil || d == nil {
+					t.Fatalf("got %v, want existing disbursement", err)
+				}
+				return
+			}
+
+			var mErr *xfers.ReferenceMismatchError
+			if !errors.As(err, &mErr) || mErr.Field != tt.field {
+				t.Fatalf("got %v, want mismatch %s", err, tt.field)
+			}
+			if d != nil || code != http.StatusConflict || !xfers.IsDuplicateReference(err) {
+				t.Fatalf("got %v %d, want nil %d", d, code, http.StatusConflict)
+			}
+		})
+	}
+}
+
+func TestCreatePaymentMethodIdempotent(t *testing.T) {
+	tests := []struct {
+		name      string
+		key       string
+		wantPosts int32
+	}{
+		{name: "without key", wantPosts: 1},
+		{name: "with key", key: "key-1", wantPosts: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var posts int32
+			client, _ := newTestClient(t, xfers.Option{
+				Idempotency: xfers.IdempotencyPolicy{MaxAttempts: 3},
+				Middlewares: []xfers.Middleware{dropResponse(1, &posts)},
+			})
+
+			_, _, err := client.CreatePaymentMethod(xfers.CreatePaymentMethodRequest{
+				Type:           xfers.PaymentVA,
+				ReferenceID:    "pm-1",
+				DisplayName:    "Budi",
+				BankShortCode:  xfers.BankBNI,
+				IdempotencyKey: tt.key,
+			})
+			if tt.key == "" && !errors.Is(err, errTimeout) {
+				t.Fatalf("got %v, want %v", err, errTimeout)
+			}
+			if posts != tt.wantPosts {
+				t.Fatalf("got %d create requests, want %d", posts, tt.wantPosts)
+			}
+		})
+	}
+}
+
+type businessMetrics struct {
+	payments      int
+	disbursements int
+}
+
+func (m *businessMetrics) ObserveRequest(ctx context.Context, metric xfers.RequestMetric) {}
+
+func (m *businessMetrics) ObservePaymentCreated(ctx context.Context, paymentType xfers.PaymentType, amount xfers.Amount, err error) {
+	m.payments++
+}
+
+func (m *businessMetrics) ObserveDisbursementCreated(ctx context.Context, bankCode xfers.BankCode, amount xfers.Amount, err error) {
+	m.disbursements++
+}
+
+func TestCreateIdempotentExisting(t *testing.T) {
+	var metrics businessMetrics
+	client, srv := newTestClient(t, xfers.Option{
+		Idempotency: xfers.IdempotencyPolicy{MaxAttempts: 3},
+		Metrics:     &metrics,
+	})
+
+	if _, _, err := seedClient(srv).CreateDisbursement(disbursementRequest("r1", 100000)); err != nil {
+		t.Fatal(err)
+	}
+
+	payment := xfers.CreatePaymentRequest{
+		PaymentMethodType: xfers.PaymentQRIS,
+		Amount:            xfers.NewAmount(10000),
+		ReferenceID:       "p1",
+		ExpiredAt:         time.Now().Add(time.Hour),
+		DisplayName:       "Budi",
+	}
+	if _, _, err := seedClient(srv).CreatePayment(payment); err != nil {
+		t.Fatal(err)
+	}
+
+	if d, _, err := client.CreateDisbursement(disbursementRequest("r1", 100000)); err != nil || d == nil {
+		t.Fatalf("got %v, want existing disbursement", err)
+	}
+	if p, _, err := client.CreatePayment(payment); err != nil || p == nil {
+		t.Fatalf("got %v, want existing payment", err)
+	}
+
+	if metrics.disbursements != 0 || metrics.payments != 0 {
+		t.Fatalf("got %+v, want existing resources not counted", metrics)
+	}
+}
@@ -0,0 +1,136 @@
+package xfers
+
+import (
+	"context"
+	"iter"
+)
+
+// PageInfo is pagination info of list response.
+type PageInfo struct {
+	Page     int
+	PageSize int
+	// Total item count. -1 if not returned by xfers.
+	TotalCount int
+	HasNext    bool
+	NextURL    string
+}
+
+// Page is a single page of list response.
+type Page[T any] struct {
+	Items []T
+	Info  PageInfo
+}
+
+// pageInfo to get page info from list response meta and links.
+func (d document[T]) pageInfo(p Pagination, count int) PageInfo {
+	info := PageInfo{
+		Page:       p.Page,
+		PageSize:   p.PageSize,
+		TotalCount: -1,
+		NextURL:    d.link("next"),
+	}
+
+	var totalCount int
+	hasTotalCount := d.meta("totalCount", &totalCount)
+
+	switch {
+	case info.NextURL != "":
+		info.HasNext = true
+	case hasTotalCount:
+		info.HasNext = p.Page*p.PageSize < totalCount
+	default:
+		info.HasNext = count > 0 && count >= p.PageSize
+	}
+
+	if hasTotalCount {
+		info.TotalCount = totalCount
+	}
+
+	return info
+}
+
+// pages to iterate pages starting from the requested page
+// until there is no next page, error, or context is done.
+func pages[T any](ctx context.Context, request Pagination, get func(context.Context, Pagination) ([]T, PageInfo, int, error)) iter.Seq2[Page[T], error] {
+	return func(yield func(Page[T], error) bool) {
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(Page[T]{}, err)
+				return
+			}
+
+			items, info, _, err := get(ctx, request)
+			if err != nil {
+				yield(Page[T]{}, err)
+				return
+			}
+
+			if !yield(Page[T]{Items: items, Info: info}, nil) {
+				return
+			}
+
+			if !info.HasNext || len(items) == 0 {
+				return
+			}
+
+			request.Page = info.Page + 1
+		}
+	}
+}
+
+func items[T any](seq iter.Seq2[Page[T], error]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for page, err := range seq {
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range page.Items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// PaymentPages to iterate all payment list pages.
+func (c *Client) PaymentPages(ctx context.Context, request Pagination) iter.Seq2[Page[Payment], error] {
+	return pages(ctx, request, c.getPayments)
+}
+
+// AllPayments to iterate all payments in all pages.
+//
+//	for payment, err := range client.AllPayments(ctx, xfers.Pagination{Status: xfers.StatusPaid}) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func (c *Client) AllPayments(ctx context.Context, request Pagination) iter.Seq2[Payment, error] {
+	return items(c.PaymentPages(ctx, request))
+}
+
+// DisbursementPages to iterate all disbursement list pages.
+func (c *Client) DisbursementPages(ctx context.Context, request Pagination) iter.Seq2[Page[Disbursement], error] {
+	return pages(ctx, request, c.getDisbursements)
+}
+
+// AllDisbursements to iterate all disbursements in all pages.
+func (c *Client) AllDisbursements(ctx context.Context, request Pagination) iter.Seq2[Disbursement, error] {
+	return items(c.DisbursementPages(ctx, request))
+}
+
+// PaymentMethodPages to iterate all payment method's payment list pages.
+func (c *Client) PaymentMethodPages(ctx context.Context, request GetPaymentMethodRequest, pagination Pagination) iter.Seq2[Page[Payment], error] {
+	return pages(ctx, pagination, func(ctx context.Context, p Pagination) ([]Payment, PageInfo, int, error) {
+		return c.getPaymentMethodPayments(ctx, request, p)
+	})
+}
+
+// AllPaymentMethodPayments to iterate all payment method's payments in all pages.
+func (c *Client) AllPaymentMethodPayments(ctx context.Context, request GetPaymentMethodRequest, pagination Pagination) iter.Seq2[Payment, error] {
+	return items(c.PaymentMethodPages(ctx, request, pagination))
+}
@@ -0,0 +1,185 @@
+package xfers
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// Document is JSON:API top level document.
+type Document struct {
+	// Single resource object, array of resource objects or null.
+	// See Resource and Resources.
+	Data     json.RawMessage            `json:"data,omitempty"`
+	Errors   []ErrorObject              `json:"errors,omitempty"`
+	Meta     map[string]json.RawMessage `json:"meta,omitempty"`
+	Links    map[string]json.RawMessage `json:"links,omitempty"`
+	Included []Resource                 `json:"included,omitempty"`
+}
+
+// Resource is JSON:API resource object.
+type Resource struct {
+	ID            string                     `json:"id,omitempty"`
+	Type          string                     `json:"type,omitempty"`
+	Attributes    json.RawMessage            `json:"attributes,omitempty"`
+	Relationships map[string]Relationship    `json:"relationships,omitempty"`
+	Links         map[string]json.RawMessage `json:"links,omitempty"`
+	Meta          map[string]json.RawMessage `json:"meta,omitempty"`
+}
+
+// Relationship is JSON:API relationship object.
+type Relationship struct {
+	// Single resource identifier, array of resource identifiers
+	// or null.
+	Data  json.RawMessage            `json:"data,omitempty"`
+	Links map[string]json.RawMessage `json:"links,omitempty"`
+	Meta  map[string]json.RawMessage `json:"meta,omitempty"`
+}
+
+// Resource to get the single resource of the document data.
+// Returns nil if the data is null or missing.
+func (d *Document) Resource() (*Resource, error) {
+	if isNull(d.Data) {
+		return nil, nil
+	}
+
+	if d.Data[0] == '[' {
+		return nil, errors.New("jsonapi: data is array")
+	}
+
+	var r Resource
+	if err := json.Unmarshal(d.Data, &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// Resources to get the resource list of the document data.
+// A single resource is returned as one item list.
+func (d *Document) Resources() ([]Resource, error) {
+	if isNull(d.Data) {
+		return nil, nil
+	}
+
+	if d.Data[0] != '[' {
+		r, err := d.Resource()
+		if err != nil {
+			return nil, err
+		}
+		return []Resource{*r}, nil
+	}
+
+	var r []Resource
+	if err := json.Unmarshal(d.Data, &r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func isNull(b json.RawMessage) bool {
+	return len(b) == 0 || string(b) == "null"
+}
+
+// document is typed JSON:API top level document used for encoding
+// request and decoding response. T is either resource[A] or
+// []resource[A].
+type document[T any] struct {
+	Data     T                          `json:"data"`
+	Meta     map[string]json.RawMessage `json:"meta,omitempty"`
+	Links    map[string]json.RawMessage `json:"links,omitempty"`
+	Included []Resource                 `json:"included,omitempty"`
+}
+
+// resource is typed JSON:API resource object with attributes A.
+type resource[A any] struct {
+	ID            string                     `json:"id,omitempty"`
+	Type          string                     `json:"type,omitempty"`
+	Attributes    A                          `json:"attributes"`
+	Relationships map[string]Relationship    `json:"relationships,omitempty"`
+	Links         map[string]json.RawMessage `json:"links,omitempty"`
+	Meta          map[string]json.RawMessage `json:"meta,omitempty"`
+}
+
+// newDocument to create request document with the attributes.
+func newDocument[A any](attributes A) document[resource[A]] {
+	return document[resource[A]]{
+		Data: resource[A]{Attributes: attributes},
+	}
+}
+
+// mapResources to convert resource list to model list.
+func mapResources[A, M any](resources []resource[A], fn func(resource[A]) M) []M {
+	res := make([]M, len(resources))
+	for i, r := range resources {
+		res[i] = fn(r)
+	}
+	return res
+}
+
+// meta to decode meta value by key. Returns false if not exist
+// or invalid.
+func (d document[T]) meta(key string, v interface{}) bool {
+	raw, ok := d.Meta[key]
+	if !ok || isNull(raw) {
+		return false
+	}
+	return json.Unmarshal(raw, v) == nil
+}
+
+// link to get link url by key. The link can be a string or
+// a link object with href.
+func (d document[T]) link(key string) string {
+	raw, ok := d.Links[key]
+	if !ok || isNull(raw) {
+		return ""
+	}
+
+	var href string
+	if json.Unmarshal(raw, &href) == nil {
+		return href
+	}
+
+	var obj struct {
+		Href string `json:"href"`
+	}
+	if json.Unmarshal(raw, &obj) == nil {
+		return obj.Href
+	}
+
+	return ""
+}
+
+// attributesOf to encode request model to JSON:API attributes using
+// its jsonapi tags. Dot in the tag name nests the field in an object,
+// e.g. `jsonapi:"disbursementMethod.type"`. Field without the tag is
+// not sent.
+func attributesOf(request interface{}) map[string]interface{} {
+	res := make(map[string]interface{})
+
+	v := reflect.Indirect(reflect.ValueOf(request))
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		name := t.Field(i).Tag.Get("jsonapi")
+		if name == "" {
+			continue
+		}
+
+		keys := strings.Split(name, ".")
+
+		obj := res
+		for _, k := range keys[:len(keys)-1] {
+			child, ok := obj[k].(map[string]interface{})
+			if !ok {
+				child = make(map[string]interface{})
+				obj[k] = child
+			}
+			obj = child
+		}
+
+		obj[keys[len(keys)-1]] = v.Field(i).Interface()
+	}
+
+	return res
+}
@@ -0,0 +1,22 @@
+package xfers
+
+import "context"
+
+// BusinessMetricsRecorder is optional MetricsRecorder extension
+// to record payment and disbursement creation.
+type BusinessMetricsRecorder interface {
+	ObservePaymentCreated(ctx context.Context, paymentType PaymentType, amount Amount, err error)
+	ObserveDisbursementCreated(ctx context.Context, bankCode BankCode, amount Amount, err error)
+}
+
+func (c *Client) observePaymentCreated(ctx context.Context, request CreatePaymentRequest, err error) {
+	if r, ok := c.metrics.(BusinessMetricsRecorder); ok {
+		r.ObservePaymentCreated(ctx, request.PaymentMethodType, request.Amount, err)
+	}
+}
+
+func (c *Client) observeDisbursementCreated(ctx context.Context, request CreateDisbursementRequest, err error) {
+	if r, ok := c.metrics.(BusinessMetricsRecorder); ok {
+		r.ObserveDisbursementCreated(ctx, request.BankShortCode, request.Amount, err)
+	}
+}
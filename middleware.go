@@ -0,0 +1,144 @@
+package xfers
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// RequesterFunc is function adapter for Requester.
+type RequesterFunc func(ctx context.Context, method, url, apiKey, secretKey string, header http.Header, request interface{}, response interface{}) (int, error)
+
+// Call to call the function.
+func (f RequesterFunc) Call(ctx context.Context, method, url, apiKey, secretKey string, header http.Header, request interface{}, response interface{}) (int, error) {
+	return f(ctx, method, url, apiKey, secretKey, header, request, response)
+}
+
+// Middleware is function wrapping Requester to add behavior
+// like logging, retry and metrics.
+type Middleware func(next Requester) Requester
+
+// Chain to wrap requester with middlewares. The first middleware
+// is the outermost one and will be called first.
+func Chain(requester Requester, middlewares ...Middleware) Requester {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		requester = middlewares[i](requester)
+	}
+	return requester
+}
+
+// HeaderMiddleware to create middleware adding the header
+// to every request. Existing request header is not replaced.
+func HeaderMiddleware(header http.Header) Middleware {
+	return func(next Requester) Requester {
+		return RequesterFunc(func(ctx context.Context, method, url, apiKey, secretKey string, h http.Header, request interface{}, response interface{}) (int, error) {
+			if h == nil {
+				h = make(http.Header)
+			} else {
+				h = h.Clone()
+			}
+
+			for k, values := range header {
+				if h.Get(k) != "" {
+					continue
+				}
+				for _, v := range values {
+					h.Add(k, v)
+				}
+			}
+
+			return next.Call(ctx, method, url, apiKey, secretKey, h, request, response)
+		})
+	}
+}
+
+// LoggingMiddleware to create middleware logging every call
+// with its operation, status and latency. Structured attributes
+// are used if the logger implements StructuredLogger.
+func LoggingMiddleware(logger Logger) Middleware {
+	return func(next Requester) Requester {
+		return RequesterFunc(func(ctx context.Context, method, url, apiKey, secretKey string, header http.Header, request interface{}, response interface{}) (int, error) {
+			now := time.Now()
+			code, err := next.Call(ctx, method, url, apiKey, secretKey, header, request, response)
+			latency := time.Since(now)
+
+			level := LogInfo
+			if err != nil {
+				level = LogError
+			}
+
+			if sl, ok := logger.(StructuredLogger); ok {
+				attrs := []slog.Attr{
+					slog.String("operation", string(OperationFromContext(ctx))),
+					slog.String("method", method),
+					slog.String("url", url),
+					slog.Int("status", code),
+					slog.Duration("latency", latency),
+				}
+				if err != nil {
+					attrs = append(attrs, slog.String("error", err.Error()))
+				}
+				sl.Log(ctx, level, "xfers call", attrs...)
+				return code, err
+			}
+
+			if err != nil {
+				logger.Error("%s %s %s %d [%s]: %s", OperationFromContext(ctx), method, url, code, latency, err.Error())
+				return code, err
+			}
+
+			logger.Info("%s %s %s %d [%s]", OperationFromContext(ctx), method, url, code, latency)
+			return code, err
+		})
+	}
+}
+
+// RequestMetric is metric of a single client call.
+type RequestMetric struct {
+	Operation  Operation
+	Method     string
+	StatusCode int
+	Latency    time.Duration
+	// Attempt number set by RetryMiddleware.
+	Attempt int
+	// Error code from xfers if available.
+	ErrorCode string
+	Err       error
+}
+
+// MetricsRecorder is interface to record client call metrics.
+type MetricsRecorder interface {
+	ObserveRequest(ctx context.Context, metric RequestMetric)
+}
+
+// MetricsMiddleware to create middleware recording every call
+// to the metrics recorder. Put it after RetryMiddleware to record
+// every attempt.
+func MetricsMiddleware(recorder MetricsRecorder) Middleware {
+	return func(next Requester) Requester {
+		return RequesterFunc(func(ctx context.Context, method, url, apiKey, secretKey string, header http.Header, request interface{}, response interface{}) (int, error) {
+			now := time.Now()
+			code, err := next.Call(ctx, method, url, apiKey, secretKey, header, request, response)
+
+			metric := RequestMetric{
+				Operation:  OperationFromContext(ctx),
+				Method:     method,
+				StatusCode: code,
+				Latency:    time.Since(now),
+				Attempt:    AttemptFromContext(ctx),
+				Err:        err,
+			}
+
+			var apiErr *APIError
+			if errors.As(err, &apiErr) && len(apiErr.Errors) > 0 {
+				metric.ErrorCode = apiErr.Errors[0].Code
+			}
+
+			recorder.ObserveRequest(ctx, metric)
+
+			return code, err
+		})
+	}
+}
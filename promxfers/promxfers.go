@@ -0,0 +1,158 @@
+// Package promxfers provides prometheus metrics for xfers client.
+// It is a separate module so the xfers module doesn't depend on
+// prometheus.
+//
+//	metrics, err := promxfers.New(prometheus.DefaultRegisterer)
+//	if err != nil {
+//		return err
+//	}
+//
+//	client := xfers.New(xfers.Option{
+//		APIKey:    "api-key",
+//		SecretKey: "secret-key",
+//		BaseURL:   "https://sandbox-id.xfers.com/api/v4",
+//		Metrics:   metrics,
+//	})
+package promxfers
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rl404/xfers-go"
+)
+
+// Metrics is xfers.MetricsRecorder and xfers.BusinessMetricsRecorder
+// exporting prometheus metrics.
+//
+// Exported metrics:
+//   - xfers_requests_total{operation,status}
+//   - xfers_request_duration_seconds{operation,status}
+//   - xfers_request_retries_total{operation}
+//   - xfers_payments_created_total{payment_type,result}
+//   - xfers_payments_created_amount_total{payment_type}
+//   - xfers_disbursements_created_total{bank_code,result}
+//   - xfers_disbursements_created_amount_total{bank_code}
+type Metrics struct {
+	requests           *prometheus.CounterVec
+	latency            *prometheus.HistogramVec
+	retries            *prometheus.CounterVec
+	payments           *prometheus.CounterVec
+	paymentAmount      *prometheus.CounterVec
+	disbursements      *prometheus.CounterVec
+	disbursementAmount *prometheus.CounterVec
+}
+
+// New to create and register prometheus metrics to the registerer.
+// Use prometheus.NewRegistry() for a local registry or
+// prometheus.DefaultRegisterer for the global one.
+func New(registerer prometheus.Registerer) (*Metrics, error) {
+	m := &Metrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "xfers",
+			Name:      "requests_total",
+			Help:      "Total xfers API requests.",
+		}, []string{"operation", "status"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "xfers",
+			Name:      "request_duration_seconds",
+			Help:      "Xfers API request latency.",
+			Buckets:   []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+		}, []string{"operation", "status"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "xfers",
+			Name:      "request_retries_total",
+			Help:      "Total retried xfers API requests.",
+		}, []string{"operation"}),
+		payments: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "xfers",
+			Name:      "payments_created_total",
+			Help:      "Total create payment calls.",
+		}, []string{"payment_type", "result"}),
+		paymentAmount: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "xfers",
+			Name:      "payments_created_amount_total",
+			Help:      "Total amount of created payments in IDR.",
+		}, []string{"payment_type"}),
+		disbursements: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "xfers",
+			Name:      "disbursements_created_total",
+			Help:      "Total create disbursement calls.",
+		}, []string{"bank_code", "result"}),
+		disbursementAmount: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "xfers",
+			Name:      "disbursements_created_amount_total",
+			Help:      "Total amount of created disbursements in IDR.",
+		}, []string{"bank_code"}),
+	}
+
+	for _, c := range []prometheus.Collector{
+		m.requests,
+		m.latency,
+		m.retries,
+		m.payments,
+		m.paymentAmount,
+		m.disbursements,
+		m.disbursementAmount,
+	} {
+		if err := registerer.Register(c); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+// statusLabel to get the response status code. Failed request
+// without xfers response (e.g. transport error) is labelled "error"
+// so it is not counted as 5xx.
+func statusLabel(code int, err error) string {
+	var apiErr *xfers.APIError
+	if err != nil && !errors.As(err, &apiErr) {
+		return "error"
+	}
+	return strconv.Itoa(code)
+}
+
+func resultLabel(err error) string {
+	var apiErr *xfers.APIError
+	switch {
+	case err == nil:
+		return "success"
+	case errors.As(err, &apiErr):
+		return "api_error"
+	default:
+		return "error"
+	}
+}
+
+// ObserveRequest to record request count, latency and retry.
+func (m *Metrics) ObserveRequest(ctx context.Context, metric xfers.RequestMetric) {
+	op := string(metric.Operation)
+	status := statusLabel(metric.StatusCode, metric.Err)
+
+	m.requests.WithLabelValues(op, status).Inc()
+	m.latency.WithLabelValues(op, status).Observe(metric.Latency.Seconds())
+
+	if metric.Attempt > 1 {
+		m.retries.WithLabelValues(op).Inc()
+	}
+}
+
+// ObservePaymentCreated to record created payment count and amount.
+func (m *Metrics) ObservePaymentCreated(ctx context.Context, paymentType xfers.PaymentType, amount xfers.Amount, err error) {
+	m.payments.WithLabelValues(string(paymentType), resultLabel(err)).Inc()
+	if err == nil {
+		m.paymentAmount.WithLabelValues(string(paymentType)).Add(amount.Float64())
+	}
+}
+
+// ObserveDisbursementCreated to record created disbursement count and amount.
+func (m *Metrics) ObserveDisbursementCreated(ctx context.Context, bankCode xfers.BankCode, amount xfers.Amount, err error) {
+	m.disbursements.WithLabelValues(string(bankCode), resultLabel(err)).Inc()
+	if err == nil {
+		m.disbursementAmount.WithLabelValues(string(bankCode)).Add(amount.Float64())
+	}
+}
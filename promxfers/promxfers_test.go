@@ -0,0 +1,96 @@
+package promxfers_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rl404/xfers-go"
+	"github.com/rl404/xfers-go/promxfers"
+	"github.com/rl404/xfers-go/xferstest"
+)
+
+func TestMetrics(t *testing.T) {
+	srv := xferstest.NewServer("api-key", "secret-key")
+	defer srv.Close()
+
+	if err := srv.SetBalance("1000000"); err != nil {
+		t.Fatal(err)
+	}
+
+	registry := prometheus.NewRegistry()
+	metrics, err := promxfers.New(registry)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := xfers.New(xfers.Option{
+		APIKey:    "api-key",
+		SecretKey: "secret-key",
+		BaseURL:   srv.URL,
+		Metrics:   metrics,
+	})
+
+	if _, _, err := client.GetBalance(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := client.CreateDisbursement(xfers.CreateDisbursementRequest{
+		ReferenceID:           "r1",
+		Type:                  xfers.DisbursementBankTransfer,
+		BankAccountHolderName: "Budi",
+		BankAccountNo:         "1234567890",
+		BankShortCode:         xfers.BankBCA,
+		Amount:                xfers.NewAmount(100000),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := client.GetDisbursement("missing"); !xfers.IsNotFound(err) {
+		t.Fatalf("got %v, want not found", err)
+	}
+
+	down := xfers.New(xfers.Option{BaseURL: "http://127.0.0.1:1", Metrics: metrics})
+	if _, _, err := down.GetBanks(); !errors.Is(err, xfers.ErrInternal) {
+		t.Fatalf("got %v, want %v", err, xfers.ErrInternal)
+	}
+
+	tests := []struct {
+		name   string
+		labels []string
+		want   float64
+	}{
+		{name: "xfers_requests_total", labels: []string{string(xfers.OpGetBalance), "200"}, want: 1},
+		{name: "xfers_requests_total", labels: []string{string(xfers.OpCreateDisbursement), "201"}, want: 1},
+		{name: "xfers_requests_total", labels: []string{string(xfers.OpGetDisbursement), "404"}, want: 1},
+		{name: "xfers_requests_total", labels: []string{string(xfers.OpGetBanks), "error"}, want: 1},
+		{name: "xfers_requests_total", labels: []string{string(xfers.OpGetBanks), "500"}, want: 0},
+		{name: "xfers_disbursements_created_total", labels: []string{"BCA", "success"}, want: 1},
+		{name: "xfers_disbursements_created_amount_total", labels: []string{"BCA"}, want: 100000},
+	}
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Label values are sorted by label name.
+	values := make(map[string]float64)
+	for _, f := range families {
+		for _, m := range f.GetMetric() {
+			key := f.GetName()
+			for _, l := range m.GetLabel() {
+				key += " " + l.GetValue()
+			}
+			values[key] = m.GetCounter().GetValue()
+		}
+	}
+
+	for _, tt := range tests {
+		key := strings.Join(append([]string{tt.name}, tt.labels...), " ")
+		if got := values[key]; got != tt.want {
+			t.Errorf("%s: got %v, want %v", key, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package xfers
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RateLimitPolicy is config for client side rate limiting.
+type RateLimitPolicy struct {
+	// Allowed requests per second. 0 means unlimited.
+	Rate float64
+	// Max burst requests. Default is ceil(Rate).
+	Burst int
+	// Max concurrent requests. 0 means unlimited.
+	MaxInFlight int
+	// Max duration the requests are paused after 429 response
+	// including the one from Retry-After header. Default is
+	// 30 seconds.
+	MaxPause time.Duration
+}
+
+func (p RateLimitPolicy) enabled() bool {
+	return p.Rate > 0 || p.MaxInFlight > 0
+}
+
+// defaultRetryAfter is pause duration when xfers responds
+// 429 without Retry-After header.
+const defaultRetryAfter = time.Second
+
+// defaultMaxPause is default max pause duration after 429 response.
+const defaultMaxPause = 30 * time.Second
+
+// limiter is token bucket rate limiter with concurrency cap.
+type limiter struct {
+	mu          sync.Mutex
+	rate        float64
+	burst       float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+	maxPause    time.Duration
+	sem         chan struct{}
+}
+
+func newLimiter(policy RateLimitPolicy) *limiter {
+	l := &limiter{
+		rate:     policy.Rate,
+		burst:    float64(policy.Burst),
+		last:     time.Now(),
+		maxPause: policy.MaxPause,
+	}
+
+	if l.maxPause <= 0 {
+		l.maxPause = defaultMaxPause
+	}
+
+	if l.burst <= 0 {
+		l.burst = math.Max(1, math.Ceil(policy.Rate))
+	}
+
+	l.tokens = l.burst
+
+	if policy.MaxInFlight > 0 {
+		l.sem = make(chan struct{}, policy.MaxInFlight)
+	}
+
+	return l
+}
+
+// wait to wait until a token is available, the pause is over
+// and there is a free in-flight slot. Release must be called
+// after the request if wait returns no error.
+func (l *limiter) wait(ctx context.Context) error {
+	for {
+		delay := l.reserve()
+		if delay <= 0 {
+			break
+		}
+		if err := sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+
+	if l.sem == nil {
+		return nil
+	}
+
+	select {
+	case l.sem <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// reserve to take a token. Returns the waiting duration
+// if no token is available.
+func (l *limiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if now.Before(l.pausedUntil) {
+		return l.pausedUntil.Sub(now)
+	}
+
+	if l.rate <= 0 {
+		return 0
+	}
+
+	l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+}
+
+func (l *limiter) release() {
+	if l.sem != nil {
+		<-l.sem
+	}
+}
+
+// pause to stop all requests for the duration capped by max pause.
+func (l *limiter) pause(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	d = min(d, l.maxPause)
+
+	if until := time.Now().Add(d); until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+}
+
+// RateLimitMiddleware to create middleware limiting request rate
+// and concurrent requests globally and per operation. Waiting
+// is cancelled when the context is done.
+//
+// When xfers responds 429, all requests are paused following the
+// Retry-After header (1 second if not set) capped by MaxPause.
+// Cancelled waiting returns status code 0 with the context error.
+func RateLimitMiddleware(global RateLimitPolicy, operations map[Operation]RateLimitPolicy) Middleware {
+	var limiters []*limiter
+	if global.enabled() {
+		limiters = append(limiters, newLimiter(global))
+	}
+
+	opLimiters := make(map[Operation]*limiter)
+	for op, p := range operations {
+		if p.enabled() {
+			opLimiters[op] = newLimiter(p)
+		}
+	}
+
+	return func(next Requester) Requester {
+		if len(limiters) == 0 && len(opLimiters) == 0 {
+			return next
+		}
+
+		return RequesterFunc(func(ctx context.Context, method, url, apiKey, secretKey string, header http.Header, request interface{}, response interface{}) (int, error) {
+			ls := limiters
+			if l, ok := opLimiters[OperationFromContext(ctx)]; ok {
+				ls = append([]*limiter{l}, limiters...)
+			}
+
+			for i, l := range ls {
+				if err := l.wait(ctx); err != nil {
+					for _, acquired := range ls[:i] {
+						acquired.release()
+					}
+					return 0, err
+				}
+			}
+
+			code, err := next.Call(ctx, method, url, apiKey, secretKey, header, request, response)
+
+			for _, l := range ls {
+				l.release()
+			}
+
+			var apiErr *APIError
+			if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusTooManyRequests {
+				d := parseRetryAfter(apiErr.Header)
+				if d <= 0 {
+					d = defaultRetryAfter
+				}
+				for _, l := range ls {
+					l.pause(d)
+				}
+			}
+
+			return code, err
+		})
+	}
+}
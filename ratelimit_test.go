@@ -0,0 +1,63 @@
+package xfers_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/rl404/xfers-go"
+)
+
+func TestRateLimitMiddlewareMaxPause(t *testing.T) {
+	var calls int
+	requester := xfers.Chain(xfers.RequesterFunc(func(ctx context.Context, method, url, apiKey, secretKey string, header http.Header, request interface{}, response interface{}) (int, error) {
+		calls++
+		if calls == 1 {
+			return http.StatusTooManyRequests, &xfers.APIError{
+				StatusCode: http.StatusTooManyRequests,
+				Header:     http.Header{"Retry-After": []string{"3600"}},
+			}
+		}
+		return http.StatusOK, nil
+	}), xfers.RateLimitMiddleware(xfers.RateLimitPolicy{MaxInFlight: 1, MaxPause: 20 * time.Millisecond}, nil))
+
+	if _, err := requester.Call(context.Background(), http.MethodGet, "url", "", "", nil, nil, nil); err == nil {
+		t.Fatal("got nil, want 429 error")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	now := time.Now()
+	if _, err := requester.Call(ctx, http.MethodGet, "url", "", "", nil, nil, nil); err != nil {
+		t.Fatalf("got %v, want pause capped by max pause", err)
+	}
+	if d := time.Since(now); d < 10*time.Millisecond {
+		t.Fatalf("got %s, want paused", d)
+	}
+}
+
+func TestRateLimitMiddlewareCancelled(t *testing.T) {
+	var calls int
+	requester := xfers.Chain(xfers.RequesterFunc(func(ctx context.Context, method, url, apiKey, secretKey string, header http.Header, request interface{}, response interface{}) (int, error) {
+		calls++
+		return http.StatusOK, nil
+	}), xfers.RateLimitMiddleware(xfers.RateLimitPolicy{Rate: 1.0 / 3600, Burst: 1}, nil))
+
+	if _, err := requester.Call(context.Background(), http.MethodGet, "url", "", "", nil, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	code, err := requester.Call(ctx, http.MethodGet, "url", "", "", nil, nil, nil)
+	if !errors.Is(err, context.DeadlineExceeded) || code != 0 {
+		t.Fatalf("got %d %v, want 0 %v", code, err, context.DeadlineExceeded)
+	}
+	if calls != 1 {
+		t.Fatalf("got %d calls, want 1", calls)
+	}
+}
@@ -0,0 +1,85 @@
+package reconcile
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+)
+
+var csvHeader = []string{
+	"kind",
+	"reference_id",
+	"result",
+	"issues",
+	"local_amount",
+	"remote_amount",
+	"amount_diff",
+	"local_fees",
+	"remote_fees",
+	"local_status",
+	"remote_status",
+	"remote_id",
+	"remote_created_at",
+}
+
+// WriteCSV to write the report items as CSV.
+func (r *Report) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, item := range r.Items {
+		issues := make([]string, len(item.Issues))
+		for i, is := range item.Issues {
+			issues[i] = string(is)
+		}
+
+		row := []string{
+			string(item.Kind),
+			item.ReferenceID,
+			string(item.Result),
+			strings.Join(issues, ";"),
+			"", "", "", "", "", "", "", "", "",
+		}
+
+		if l := item.Local; l != nil {
+			row[4] = l.Amount.String()
+			if l.Fees != nil {
+				row[7] = l.Fees.String()
+			}
+			row[9] = string(l.Status)
+		}
+
+		if rm := item.Remote; rm != nil {
+			row[5] = rm.Amount.String()
+			row[8] = rm.Fees.String()
+			row[10] = string(rm.Status)
+			row[11] = rm.ID
+			if !rm.CreatedAt.IsZero() {
+				row[12] = rm.CreatedAt.Format(time.RFC3339)
+			}
+		}
+
+		if item.Local != nil && item.Remote != nil {
+			row[6] = item.AmountDiff.String()
+		}
+
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteJSON to write the report as JSON.
+func (r *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
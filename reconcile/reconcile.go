@@ -0,0 +1,248 @@
+// Package reconcile matches local ledger records against xfers
+// payments and disbursements.
+//
+//	report, err := reconcile.Disbursements(ctx, client, records, reconcile.Option{
+//		From: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+//		To:   time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+//	})
+//	if err != nil {
+//		return err
+//	}
+//
+//	report.WriteCSV(os.Stdout)
+package reconcile
+
+import (
+	"context"
+	"iter"
+	"sort"
+	"time"
+
+	"github.com/rl404/xfers-go"
+)
+
+// Client is xfers client used for fetching the transactions.
+// *xfers.Client implements it.
+type Client interface {
+	AllPayments(ctx context.Context, request xfers.Pagination) iter.Seq2[xfers.Payment, error]
+	AllDisbursements(ctx context.Context, request xfers.Pagination) iter.Seq2[xfers.Disbursement, error]
+}
+
+// Kind is type for transaction kind.
+type Kind string
+
+// Available options for Kind.
+const (
+	KindPayment      Kind = "payment"
+	KindDisbursement Kind = "disbursement"
+)
+
+// Result is type for reconciliation result of a transaction.
+type Result string
+
+// Available options for Result.
+const (
+	Matched       Result = "matched"
+	Mismatched    Result = "mismatched"
+	MissingRemote Result = "missing_remote" // in ledger, not in xfers
+	MissingLocal  Result = "missing_local"  // in xfers, not in ledger
+	Duplicate     Result = "duplicate"      // same reference id more than once
+)
+
+// Issue is type for mismatch detail.
+type Issue string
+
+// Available options for Issue.
+const (
+	IssueAmount Issue = "amount"
+	IssueFees   Issue = "fees"
+	IssueStatus Issue = "status"
+)
+
+// Record is local ledger record.
+type Record struct {
+	ReferenceID string       `json:"referenceId"`
+	Amount      xfers.Amount `json:"amount"`
+	// Expected fees. Nil means not checked.
+	Fees *xfers.Amount `json:"fees,omitempty"`
+	// Expected status. Empty means not checked.
+	Status xfers.Status `json:"status,omitempty"`
+}
+
+// Remote is xfers transaction.
+type Remote struct {
+	ID          string       `json:"id"`
+	ReferenceID string       `json:"referenceId"`
+	Amount      xfers.Amount `json:"amount"`
+	Fees        xfers.Amount `json:"fees"`
+	Status      xfers.Status `json:"status"`
+	CreatedAt   time.Time    `json:"createdAt"`
+}
+
+// Item is reconciliation result of a single reference id.
+type Item struct {
+	Kind        Kind    `json:"kind"`
+	ReferenceID string  `json:"referenceId"`
+	Result      Result  `json:"result"`
+	Issues      []Issue `json:"issues,omitempty"`
+	Local       *Record `json:"local,omitempty"`
+	Remote      *Remote `json:"remote,omitempty"`
+	// Remote amount minus local amount.
+	AmountDiff xfers.Amount `json:"amountDiff"`
+}
+
+// Report is reconciliation report.
+type Report struct {
+	Kind    Kind           `json:"kind"`
+	From    time.Time      `json:"from"`
+	To      time.Time      `json:"to"`
+	Items   []Item         `json:"items"`
+	Summary map[Result]int `json:"summary"`
+}
+
+// Option is config for reconciliation.
+type Option struct {
+	// Date range of xfers transactions, used as
+	// Pagination.CreatedAfter and CreatedBefore. Local records
+	// should be from the same range, otherwise they are reported
+	// as missing.
+	From time.Time
+	To   time.Time
+	// Page size when fetching the transactions. Default is 100.
+	PageSize int
+}
+
+func (o Option) pagination() xfers.Pagination {
+	if o.PageSize <= 0 {
+		o.PageSize = 100
+	}
+
+	return xfers.Pagination{
+		Page:          1,
+		PageSize:      o.PageSize,
+		CreatedAfter:  o.From,
+		CreatedBefore: o.To,
+	}
+}
+
+// Payments to reconcile local records against xfers payments.
+func Payments(ctx context.Context, client Client, records []Record, option Option) (*Report, error) {
+	var remotes []Remote
+	for p, err := range client.AllPayments(ctx, option.pagination()) {
+		if err != nil {
+			return nil, err
+		}
+		remotes = append(remotes, Remote{
+			ID:          p.ID,
+			ReferenceID: p.ReferenceID,
+			Amount:      p.Amount,
+			Fees:        p.Fees,
+			Status:      p.Status,
+			CreatedAt:   p.CreatedAt,
+		})
+	}
+
+	return reconcile(KindPayment, option, records, remotes), nil
+}
+
+// Disbursements to reconcile local records against xfers disbursements.
+func Disbursements(ctx context.Context, client Client, records []Record, option Option) (*Report, error) {
+	var remotes []Remote
+	for d, err := range client.AllDisbursements(ctx, option.pagination()) {
+		if err != nil {
+			return nil, err
+		}
+		remotes = append(remotes, Remote{
+			ID:          d.ID,
+			ReferenceID: d.ReferenceID,
+			Amount:      d.Amount,
+			Fees:        d.Fees,
+			Status:      d.Status,
+			CreatedAt:   d.CreatedAt,
+		})
+	}
+
+	return reconcile(KindDisbursement, option, records, remotes), nil
+}
+
+func reconcile(kind Kind, option Option, records []Record, remotes []Remote) *Report {
+	report := &Report{
+		Kind:    kind,
+		From:    option.From,
+		To:      option.To,
+		Summary: make(map[Result]int),
+	}
+
+	add := func(item Item) {
+		item.Kind = kind
+		report.Items = append(report.Items, item)
+		report.Summary[item.Result]++
+	}
+
+	remoteByRef := make(map[string]Remote)
+	for _, r := range remotes {
+		if _, ok := remoteByRef[r.ReferenceID]; ok {
+			add(Item{ReferenceID: r.ReferenceID, Result: Duplicate, Remote: &r})
+			continue
+		}
+		remoteByRef[r.ReferenceID] = r
+	}
+
+	seen := make(map[string]bool)
+	for _, l := range records {
+		if seen[l.ReferenceID] {
+			add(Item{ReferenceID: l.ReferenceID, Result: Duplicate, Local: &l})
+			continue
+		}
+		seen[l.ReferenceID] = true
+
+		r, ok := remoteByRef[l.ReferenceID]
+		if !ok {
+			add(Item{ReferenceID: l.ReferenceID, Result: MissingRemote, Local: &l})
+			continue
+		}
+
+		add(compare(l, r))
+	}
+
+	for _, r := range remotes {
+		if !seen[r.ReferenceID] {
+			seen[r.ReferenceID] = true
+			add(Item{ReferenceID: r.ReferenceID, Result: MissingLocal, Remote: &r})
+		}
+	}
+
+	sort.SliceStable(report.Items, func(i, j int) bool {
+		return report.Items[i].ReferenceID < report.Items[j].ReferenceID
+	})
+
+	return report
+}
+
+func compare(l Record, r Remote) Item {
+	item := Item{
+		ReferenceID: l.ReferenceID,
+		Result:      Matched,
+		Local:       &l,
+		Remote:      &r,
+		AmountDiff:  r.Amount.Sub(l.Amount),
+	}
+
+	if r.Amount.Cmp(l.Amount) != 0 {
+		item.Issues = append(item.Issues, IssueAmount)
+	}
+
+	if l.Fees != nil && r.Fees.Cmp(*l.Fees) != 0 {
+		item.Issues = append(item.Issues, IssueFees)
+	}
+
+	if l.Status != "" && r.Status != l.Status {
+		item.Issues = append(item.Issues, IssueStatus)
+	}
+
+	if len(item.Issues) > 0 {
+		item.Result = Mismatched
+	}
+
+	return item
+}
@@ -0,0 +1,167 @@
+package reconcile_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/rl404/xfers-go"
+	"github.com/rl404/xfers-go/reconcile"
+	"github.com/rl404/xfers-go/xferstest"
+)
+
+func newClient(t *testing.T) *xfers.Client {
+	t.Helper()
+
+	srv := xferstest.NewServer("api-key", "secret-key")
+	t.Cleanup(srv.Close)
+
+	if err := srv.SetBalance("10000000"); err != nil {
+		t.Fatal(err)
+	}
+
+	return xfers.New(xfers.Option{
+		APIKey:    "api-key",
+		SecretKey: "secret-key",
+		BaseURL:   srv.URL,
+		Env:       xfers.Sandbox,
+	})
+}
+
+func createDisbursement(t *testing.T, client *xfers.Client, referenceID string, rupiah int64, action xfers.Action) {
+	t.Helper()
+
+	d, _, err := client.CreateDisbursement(xfers.CreateDisbursementRequest{
+		ReferenceID:           referenceID,
+		Type:                  xfers.DisbursementBankTransfer,
+		BankAccountHolderName: "Budi",
+		BankAccountNo:         "1234567890",
+		BankShortCode:         xfers.BankBCA,
+		Amount:                xfers.NewAmount(rupiah),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if action != "" {
+		if _, _, err := client.SimulateDisbursement(xfers.SimulateDisbursementRequest{ID: d.ID, Action: action}); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestDisbursements(t *testing.T) {
+	client := newClient(t)
+
+	createDisbursement(t, client, "d1", 10000, xfers.ActionComplete)
+	createDisbursement(t, client, "d2", 20000, "")
+	createDisbursement(t, client, "d3", 30000, xfers.ActionComplete)
+	createDisbursement(t, client, "d4", 40000, "")
+
+	records := []reconcile.Record{
+		{ReferenceID: "d1", Amount: xfers.NewAmount(10000), Status: xfers.StatusCompleted},
+		{ReferenceID: "d2", Amount: xfers.NewAmount(25000)},
+		{ReferenceID: "d3", Amount: xfers.NewAmount(30000), Status: xfers.StatusFailed},
+		{ReferenceID: "d5", Amount: xfers.NewAmount(50000)},
+		{ReferenceID: "d1", Amount: xfers.NewAmount(10000)},
+	}
+
+	report, err := reconcile.Disbursements(context.Background(), client, records, reconcile.Option{PageSize: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		referenceID string
+		result      reconcile.Result
+		issues      []reconcile.Issue
+		amountDiff  xfers.Amount
+	}{
+		{referenceID: "d1", result: reconcile.Matched},
+		{referenceID: "d1", result: reconcile.Duplicate},
+		{referenceID: "d2", result: reconcile.Mismatched, issues: []reconcile.Issue{reconcile.IssueAmount}, amountDiff: xfers.NewAmount(-5000)},
+		{referenceID: "d3", result: reconcile.Mismatched, issues: []reconcile.Issue{reconcile.IssueStatus}},
+		{referenceID: "d4", result: reconcile.MissingLocal},
+		{referenceID: "d5", result: reconcile.MissingRemote},
+	}
+
+	if len(report.Items) != len(tests) {
+		t.Fatalf("got %d items, want %d: %+v", len(report.Items), len(tests), report.Items)
+	}
+
+	for i, tt := range tests {
+		item := report.Items[i]
+		if item.Kind != reconcile.KindDisbursement ||
+			item.ReferenceID != tt.referenceID ||
+			item.Result != tt.result ||
+			!reflect.DeepEqual(item.Issues, tt.issues) ||
+			item.AmountDiff.Cmp(tt.amountDiff) != 0 {
+			t.Errorf("item %d: got %s %s %v %s, want %s %s %v %s", i,
+				item.ReferenceID, item.Result, item.Issues, item.AmountDiff,
+				tt.referenceID, tt.result, tt.issues, tt.amountDiff)
+		}
+	}
+
+	wantSummary := map[reconcile.Result]int{
+		reconcile.Matched:       1,
+		reconcile.Duplicate:     1,
+		reconcile.Mismatched:    2,
+		reconcile.MissingLocal:  1,
+		reconcile.MissingRemote: 1,
+	}
+	if !reflect.DeepEqual(report.Summary, wantSummary) {
+		t.Fatalf("got summary %v, want %v", report.Summary, wantSummary)
+	}
+}
+
+func TestPayments(t *testing.T) {
+	client := newClient(t)
+
+	p, _, err := client.CreatePayment(xfers.CreatePaymentRequest{
+		PaymentMethodType: xfers.PaymentQRIS,
+		Amount:            xfers.NewAmount(10000),
+		ReferenceID:       "p1",
+		ExpiredAt:         time.Now().Add(time.Hour),
+		DisplayName:       "Budi",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		record reconcile.Record
+		result reconcile.Result
+		issues []reconcile.Issue
+	}{
+		{name: "matched", record: reconcile.Record{ReferenceID: "p1", Amount: p.Amount, Fees: &p.Fees, Status: p.Status}, result: reconcile.Matched},
+		{name: "fees", record: reconcile.Record{ReferenceID: "p1", Amount: p.Amount, Fees: ptr(p.Fees.Add(xfers.NewAmount(1)))}, result: reconcile.Mismatched, issues: []reconcile.Issue{reconcile.IssueFees}},
+		{name: "amount and status", record: reconcile.Record{ReferenceID: "p1", Amount: xfers.NewAmount(1), Status: xfers.StatusPaid}, result: reconcile.Mismatched, issues: []reconcile.Issue{reconcile.IssueAmount, reconcile.IssueStatus}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := reconcile.Payments(context.Background(), client, []reconcile.Record{tt.record}, reconcile.Option{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(report.Items) != 1 {
+				t.Fatalf("got %d items, want 1", len(report.Items))
+			}
+
+			item := report.Items[0]
+			if item.Kind != reconcile.KindPayment || item.Result != tt.result || !reflect.DeepEqual(item.Issues, tt.issues) {
+				t.Fatalf("got %s %s %v, want %s %v", item.Kind, item.Result, item.Issues, tt.result, tt.issues)
+			}
+			if item.Remote == nil || item.Remote.ID != p.ID {
+				t.Fatalf("got remote %+v, want %s", item.Remote, p.ID)
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,131 @@
+package xfers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+var defaultRedactFields = []string{
+	"Authorization",
+	"accountNo",
+	"accountName",
+	"bankAccountNo",
+	"bankAccountHolderName",
+	"serverBankAccountHolderName",
+	"displayName",
+	"paymentCode",
+	"suffixNo",
+}
+
+// DefaultRedactFields to get default header and json field names
+// whose values are masked before being logged.
+func DefaultRedactFields() []string {
+	return append([]string{}, defaultRedactFields...)
+}
+
+type redactor struct {
+	fields map[string]bool
+}
+
+// newRedactor to create redactor. Field names are case insensitive.
+func newRedactor(fields []string) *redactor {
+	r := &redactor{fields: make(map[string]bool, len(fields))}
+	for _, f := range fields {
+		r.fields[strings.ToLower(f)] = true
+	}
+	return r
+}
+
+func (r *redactor) match(field string) bool {
+	return r.fields[strings.ToLower(field)]
+}
+
+// header to get copy of header with redacted values.
+func (r *redactor) header(header http.Header) http.Header {
+	h := header.Clone()
+	for k, values := range h {
+		if !r.match(k) {
+			continue
+		}
+		for i, v := range values {
+			if scheme, _, ok := strings.Cut(v, " "); ok && strings.EqualFold(k, "Authorization") {
+				values[i] = scheme + " " + redactedValue
+				continue
+			}
+			values[i] = mask(v)
+		}
+	}
+	return h
+}
+
+// body to get json body with redacted values. Non-json body
+// is returned as is.
+func (r *redactor) body(body []byte) []byte {
+	if len(r.fields) == 0 {
+		return body
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+
+	var data interface{}
+	if err := dec.Decode(&data); err != nil {
+		return body
+	}
+
+	redacted, err := json.Marshal(r.walk(data))
+	if err != nil {
+		return body
+	}
+
+	return redacted
+}
+
+func (r *redactor) walk(data interface{}) interface{} {
+	switch d := data.(type) {
+	case map[string]interface{}:
+		for k, v := range d {
+			if r.match(k) {
+				switch vv := v.(type) {
+				case string:
+					d[k] = mask(vv)
+				case json.Number:
+					d[k] = mask(vv.String())
+				case nil:
+				default:
+					d[k] = redactedValue
+				}
+				continue
+			}
+			d[k] = r.walk(v)
+		}
+	case []interface{}:
+		for i, v := range d {
+			d[i] = r.walk(v)
+		}
+	}
+	return data
+}
+
+const redactedValue = "[REDACTED]"
+
+// mask to hide value. Numbers keep their last 4 digits so the
+// log is still useful for tracing, others keep the first character.
+func mask(v string) string {
+	if v == "" {
+		return v
+	}
+
+	if isDigit(v) {
+		if len(v) <= 4 {
+			return strings.Repeat("*", len(v))
+		}
+		return strings.Repeat("*", len(v)-4) + v[len(v)-4:]
+	}
+
+	_, size := utf8.DecodeRuneInString(v)
+	return v[:size] + "***"
+}
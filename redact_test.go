@@ -0,0 +1,90 @@
+package xfers_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/rl404/xfers-go"
+)
+
+type captureLogger struct {
+	logs []string
+}
+
+func (l *captureLogger) Debug(format string, args ...interface{}) {
+	l.logs = append(l.logs, fmt.Sprintf(format, args...))
+}
+
+func (l *captureLogger) Info(format string, args ...interface{}) {
+	l.logs = append(l.logs, fmt.Sprintf(format, args...))
+}
+
+func (l *captureLogger) Error(format string, args ...interface{}) {
+	l.logs = append(l.logs, fmt.Sprintf(format, args...))
+}
+
+func TestRedactLog(t *testing.T) {
+	tests := []struct {
+		name        string
+		fields      []string
+		contains    []string
+		notContains []string
+	}{
+		{
+			name: "default fields",
+			contains: []string{
+				"Authorization: Basic [REDACTED]",
+				`"bankAccountNo": "******7890"`,
+				`"bankAccountHolderName": "É***"`,
+				`"description": "gaji"`,
+			},
+			notContains: []string{"1234567890", "Éva Budi"},
+		},
+		{
+			name:        "custom fields",
+			fields:      []string{"description"},
+			contains:    []string{`"description": "g***"`, "1234567890"},
+			notContains: []string{`"gaji"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logger captureLogger
+			client, _ := newTestClient(t, xfers.Option{Logger: &logger, RedactFields: tt.fields})
+
+			request := disbursementRequest("r1", 10000)
+			request.BankAccountHolderName = "Éva Budi"
+			request.Description = "gaji"
+			if _, _, err := client.CreateDisbursement(request); err != nil {
+				t.Fatal(err)
+			}
+
+			logs := strings.Join(logger.logs, "\n")
+			if !utf8.ValidString(logs) {
+				t.Fatalf("got invalid utf-8 log %q", logs)
+			}
+			for _, s := range tt.contains {
+				if !strings.Contains(logs, s) {
+					t.Errorf("got no %s in log:\n%s", s, logs)
+				}
+			}
+			for _, s := range tt.notContains {
+				if strings.Contains(logs, s) {
+					t.Errorf("got %s in log:\n%s", s, logs)
+				}
+			}
+		})
+	}
+}
+
+func TestDefaultRedactFieldsCopy(t *testing.T) {
+	fields := xfers.DefaultRedactFields()
+	fields[0] = "changed"
+
+	if xfers.DefaultRedactFields()[0] == "changed" {
+		t.Fatal("got default fields changed by caller")
+	}
+}
@@ -17,63 +17,20 @@ func (p PaymentType) toURL() string {
 
 // ValidateBankAccountRequest is request model for validate bank account.
 type ValidateBankAccountRequest struct {
-	AccountNo     string   `json:"accountNo" validate:"required,numeric" mod:"no_space"`
-	BankShortCode BankCode `json:"bankShortCode" validate:"required,bank_code" mod:"no_space,ucase"`
-}
-
-type validateBankAccountRequest struct {
-	Data struct {
-		Attributes struct {
-			AccountNo     string   `json:"accountNo"`
-			BankShortCode BankCode `json:"bankShortCode"`
-		} `json:"attributes"`
-	} `json:"data"`
-}
-
-func (v ValidateBankAccountRequest) wrap() validateBankAccountRequest {
-	var req validateBankAccountRequest
-	req.Data.Attributes.AccountNo = v.AccountNo
-	req.Data.Attributes.BankShortCode = v.BankShortCode
-	return req
+	AccountNo     string   `json:"accountNo" jsonapi:"accountNo" validate:"required,numeric" mod:"no_space"`
+	BankShortCode BankCode `json:"bankShortCode" jsonapi:"bankShortCode" validate:"required,bank_code" mod:"no_space,ucase"`
 }
 
 // CreateDisbursementRequest is request model for create disbursement.
 type CreateDisbursementRequest struct {
-	ReferenceID           string           `validate:"required" mod:"trim"`
-	Type                  DisbursementType `validate:"required,disbursement_type" mod:"no_space,lcase"`
-	BankAccountHolderName string           `validate:"required" mod:"trim"`
-	BankAccountNo         string           `validate:"required,numeric" mod:"no_space"`
-	BankShortCode         BankCode         `validate:"required,bank_code" mod:"no_space,ucase"`
-	Amount                float64          `validate:"required,gt=0"`
-	Description           string           `mod:"trim"`
-}
-
-type createDisbursementRequest struct {
-	Data struct {
-		Attributes struct {
-			Amount             float64 `json:"amount"`
-			ReferenceID        string  `json:"referenceId"`
-			Description        string  `json:"description"`
-			DisbursementMethod struct {
-				Type                  DisbursementType `json:"type"`
-				BankShortCode         BankCode         `json:"bankShortCode"`
-				BankAccountNo         string           `json:"bankAccountNo"`
-				BankAccountHolderName string           `json:"bankAccountHolderName"`
-			} `json:"disbursementMethod"`
-		} `json:"attributes"`
-	} `json:"data"`
-}
-
-func (c CreateDisbursementRequest) wrap() createDisbursementRequest {
-	var r createDisbursementRequest
-	r.Data.Attributes.Amount = c.Amount
-	r.Data.Attributes.ReferenceID = c.ReferenceID
-	r.Data.Attributes.Description = c.Description
-	r.Data.Attributes.DisbursementMethod.Type = c.Type
-	r.Data.Attributes.DisbursementMethod.BankShortCode = c.BankShortCode
-	r.Data.Attributes.DisbursementMethod.BankAccountNo = c.BankAccountNo
-	r.Data.Attributes.DisbursementMethod.BankAccountHolderName = c.BankAccountHolderName
-	return r
+	ReferenceID           string           `jsonapi:"referenceId" validate:"required" mod:"trim"`
+	Type                  DisbursementType `jsonapi:"disbursementMethod.type" validate:"required,disbursement_type" mod:"no_space,lcase"`
+	BankAccountHolderName string           `jsonapi:"disbursementMethod.bankAccountHolderName" validate:"required" mod:"trim"`
+	BankAccountNo         string           `jsonapi:"disbursementMethod.bankAccountNo" validate:"required,numeric" mod:"no_space"`
+	BankShortCode         BankCode         `jsonapi:"disbursementMethod.bankShortCode" validate:"required,bank_code" mod:"no_space,ucase"`
+	Amount                Amount           `jsonapi:"amount" validate:"required,gt=0"`
+	Description           string           `jsonapi:"description" mod:"trim"`
+	IdempotencyKey        string           `mod:"trim"`
 }
 
 // Pagination is pagination request model.
@@ -118,63 +75,23 @@ func (p *Pagination) encode() string {
 // SimulateDisbursementRequest is request model for simulate disbursement status.
 type SimulateDisbursementRequest struct {
 	ID     string `validate:"required" mod:"no_space"`
-	Action Action `validate:"required,disbursement_action" mod:"no_space,lcase"`
-}
-
-type simulateDisbursementRequest struct {
-	Data struct {
-		Attributes struct {
-			Action Action `json:"action"`
-		} `json:"attributes"`
-	} `json:"data"`
-}
-
-func (s SimulateDisbursementRequest) wrap() simulateDisbursementRequest {
-	var r simulateDisbursementRequest
-	r.Data.Attributes.Action = s.Action
-	return r
+	Action Action `jsonapi:"action" validate:"required,disbursement_action" mod:"no_space,lcase"`
 }
 
 // CreatePaymentRequest is request model for create payment.
 type CreatePaymentRequest struct {
-	PaymentMethodType        PaymentType  `validate:"required,payment_type" mod:"no_space,lcase"`
-	Amount                   float64      `validate:"required,gt=0"`
-	ReferenceID              string       `validate:"required" mod:"trim"`
-	ExpiredAt                time.Time    `validate:"required"`
-	Description              string       `mod:"trim"`
-	DisplayName              string       `mod:"trim"`
-	RetailOutletName         RetailOutlet `mod:"no_space,ucase"` // retail outlet
-	BankShortCode            BankCode     `mod:"no_space,ucase"` // va
-	SuffixNo                 string       `mod:"no_space"`       // va
-	ProvideCode              EWallet      `mod:"no_space,ucase"` // e-wallet
-	AfterSettlementReturnURL string       `mod:"trim"`           // e-wallet
-}
-
-type createPaymentRequest struct {
-	Data struct {
-		Attributes struct {
-			PaymentMethodType    PaymentType `json:"paymentMethodType"`
-			Amount               float64     `json:"amount"`
-			ReferenceID          string      `json:"referenceId"`
-			ExpiredAt            time.Time   `json:"expiredAt"`
-			Description          string      `json:"description"`
-			PaymentMethodOptions struct {
-				// All.
-				DisplayName string `json:"displayName"`
-
-				// Retail outlet.
-				RetailOutletName RetailOutlet `json:"retailOutletName"`
-
-				// VA.
-				BankShortCode BankCode `json:"bankShortCode"`
-				SuffixNo      string   `json:"suffixNo"`
-
-				// E-wallet.
-				ProviderCode             EWallet `json:"providerCode"`
-				AfterSettlementReturnURL string  `json:"afterSettlementReturnUrl"`
-			} `json:"paymentMethodOptions"`
-		} `json:"attributes"`
-	} `json:"data"`
+	PaymentMethodType        PaymentType  `jsonapi:"paymentMethodType" validate:"required,payment_type" mod:"no_space,lcase"`
+	Amount                   Amount       `jsonapi:"amount" validate:"required,gt=0"`
+	ReferenceID              string       `jsonapi:"referenceId" validate:"required" mod:"trim"`
+	ExpiredAt                time.Time    `jsonapi:"expiredAt" validate:"required"`
+	Description              string       `jsonapi:"description" mod:"trim"`
+	DisplayName              string       `jsonapi:"paymentMethodOptions.displayName" mod:"trim"`
+	RetailOutletName         RetailOutlet `jsonapi:"paymentMethodOptions.retailOutletName" mod:"no_space,ucase"` // retail outlet
+	BankShortCode            BankCode     `jsonapi:"paymentMethodOptions.bankShortCode" mod:"no_space,ucase"`    // va
+	SuffixNo                 string       `jsonapi:"paymentMethodOptions.suffixNo" mod:"no_space"`               // va
+	ProvideCode              EWallet      `jsonapi:"paymentMethodOptions.providerCode" mod:"no_space,ucase"`     // e-wallet
+	AfterSettlementReturnURL string       `jsonapi:"paymentMethodOptions.afterSettlementReturnUrl" mod:"trim"`   // e-wallet
+	IdempotencyKey           string       `mod:"trim"`
 }
 
 type paymentRetailValidation struct {
@@ -215,65 +132,21 @@ func (c *CreatePaymentRequest) validate() error {
 	return nil
 }
 
-func (c CreatePaymentRequest) wrap() createPaymentRequest {
-	var r createPaymentRequest
-	r.Data.Attributes.PaymentMethodType = c.PaymentMethodType
-	r.Data.Attributes.Amount = c.Amount
-	r.Data.Attributes.ReferenceID = c.ReferenceID
-	r.Data.Attributes.ExpiredAt = c.ExpiredAt
-	r.Data.Attributes.Description = c.Description
-	r.Data.Attributes.PaymentMethodOptions.DisplayName = c.DisplayName
-	r.Data.Attributes.PaymentMethodOptions.RetailOutletName = c.RetailOutletName
-	r.Data.Attributes.PaymentMethodOptions.BankShortCode = c.BankShortCode
-	r.Data.Attributes.PaymentMethodOptions.SuffixNo = c.SuffixNo
-	r.Data.Attributes.PaymentMethodOptions.ProviderCode = c.ProvideCode
-	r.Data.Attributes.PaymentMethodOptions.AfterSettlementReturnURL = c.AfterSettlementReturnURL
-	return r
-}
-
 // SimulatePaymentRequest is request model for simulate payment.
 type SimulatePaymentRequest struct {
 	ID     string `validate:"required" mod:"no_space"`
-	Action Action `validate:"required,payment_action" mod:"no_space,lcase"`
-	Amount float64
-}
-
-type simulatePaymentRequest struct {
-	Data struct {
-		Attributes struct {
-			Action  Action `json:"action"`
-			Options struct {
-				Amount float64 `json:"amount"`
-			} `json:"options"`
-		} `json:"attributes"`
-	} `json:"data"`
-}
-
-func (s SimulatePaymentRequest) wrap() simulatePaymentRequest {
-	var r simulatePaymentRequest
-	r.Data.Attributes.Action = s.Action
-	r.Data.Attributes.Options.Amount = s.Amount
-	return r
+	Action Action `jsonapi:"action" validate:"required,payment_action" mod:"no_space,lcase"`
+	Amount Amount `jsonapi:"options.amount"`
 }
 
 // CreatePaymentMethodRequest is request model for create payment method.
 type CreatePaymentMethodRequest struct {
-	Type          PaymentType `validate:"required,payment_method" mod:"no_space,lcase"`
-	ReferenceID   string      `validate:"required"`
-	DisplayName   string      `validate:"required" mod:"trim"`
-	BankShortCode BankCode    `mod:"no_space,ucase"`
-	SuffixNo      string      `mod:"no_space"`
-}
-
-type createPaymentMethodRequest struct {
-	Data struct {
-		Attributes struct {
-			ReferenceID   string   `json:"referenceId"`
-			DisplayName   string   `json:"displayName"`
-			BankShortCode BankCode `json:"bankShortCode"`
-			SuffixNo      string   `json:"suffixNo"`
-		} `json:"attributes"`
-	} `json:"data"`
+	Type           PaymentType `validate:"required,payment_method" mod:"no_space,lcase"`
+	ReferenceID    string      `jsonapi:"referenceId" validate:"required"`
+	DisplayName    string      `jsonapi:"displayName" validate:"required" mod:"trim"`
+	BankShortCode  BankCode    `jsonapi:"bankShortCode" mod:"no_space,ucase"`
+	SuffixNo       string      `jsonapi:"suffixNo" mod:"no_space"`
+	IdempotencyKey string      `mod:"trim"`
 }
 
 type paymentMethodVAValidation struct {
@@ -297,15 +170,6 @@ func (c *CreatePaymentMethodRequest) validate() error {
 	return nil
 }
 
-func (c *CreatePaymentMethodRequest) wrap() createPaymentMethodRequest {
-	var r createPaymentMethodRequest
-	r.Data.Attributes.ReferenceID = c.ReferenceID
-	r.Data.Attributes.DisplayName = c.DisplayName
-	r.Data.Attributes.BankShortCode = c.BankShortCode
-	r.Data.Attributes.SuffixNo = c.SuffixNo
-	return r
-}
-
 // GetPaymentMethodRequest is request model for get payment method.
 type GetPaymentMethodRequest struct {
 	ID   string      `validate:"required" mod:"no_space"`
@@ -316,24 +180,6 @@ type GetPaymentMethodRequest struct {
 type SimulatePaymentMethodRequest struct {
 	ID     string      `validate:"required" mod:"no_space"`
 	Type   PaymentType `validate:"required,payment_method" mod:"no_space,lcase"`
-	Action Action      `validate:"required,payment_method_action" mod:"no_space,lcase"`
-	Amount float64     `validate:"required,gt=0"`
-}
-
-type simulatePaymentMethodRequest struct {
-	Data struct {
-		Attributes struct {
-			Action  Action `json:"action"`
-			Options struct {
-				Amount float64 `json:"amount"`
-			} `json:"options"`
-		} `json:"attributes"`
-	} `json:"data"`
-}
-
-func (s SimulatePaymentMethodRequest) wrap() simulatePaymentMethodRequest {
-	var r simulatePaymentMethodRequest
-	r.Data.Attributes.Action = s.Action
-	r.Data.Attributes.Options.Amount = s.Amount
-	return r
+	Action Action      `jsonapi:"action" validate:"required,payment_method_action" mod:"no_space,lcase"`
+	Amount Amount      `jsonapi:"options.amount" validate:"required,gt=0"`
 }
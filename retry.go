@@ -0,0 +1,199 @@
+package xfers
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy is config for retrying failed requests.
+//
+// Only safe requests are retried. Those are GET requests and
+// POST requests that are idempotent (validating bank account
+// or having Idempotency-Key header).
+type RetryPolicy struct {
+	// Max attempts including the first one.
+	// Less than 2 means no retry.
+	MaxAttempts int
+	// Initial backoff duration. Doubled for every attempt.
+	// Default is 200 milliseconds.
+	BaseBackoff time.Duration
+	// Max backoff duration including the one from Retry-After
+	// header. Default is 5 seconds.
+	MaxBackoff time.Duration
+	// Random fraction (0-1) subtracted from the backoff duration.
+	Jitter float64
+	// Response status codes that will be retried.
+	// Failed requests without proper response (ErrInternal)
+	// are always retried.
+	RetryableStatusCodes []int
+}
+
+// DefaultRetryPolicy to get default retry config.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseBackoff: 200 * time.Millisecond,
+		MaxBackoff:  5 * time.Second,
+		Jitter:      0.2,
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+func (p RetryPolicy) enabled() bool {
+	return p.MaxAttempts > 1
+}
+
+func (p RetryPolicy) retryableCode(code int) bool {
+	for _, c := range p.RetryableStatusCodes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff to get the waiting duration before the next attempt.
+// Retry-After header value will be used if it is longer, but
+// still capped by max backoff.
+func (p RetryPolicy) backoff(attempt int, retryAfter time.Duration) time.Duration {
+	d := p.BaseBackoff
+	for i := 1; i < attempt && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+
+	if d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+
+	if p.Jitter > 0 {
+		d -= time.Duration(rand.Float64() * p.Jitter * float64(d))
+	}
+
+	if retryAfter > d {
+		d = min(retryAfter, p.MaxBackoff)
+	}
+
+	return d
+}
+
+// RetryMiddleware to create middleware retrying failed safe requests
+// with the retry policy. Logger is optional and used to report the
+// retry attempts.
+func RetryMiddleware(policy RetryPolicy, logger Logger) Middleware {
+	return func(next Requester) Requester {
+		if !policy.enabled() {
+			return next
+		}
+
+		if policy.BaseBackoff <= 0 {
+			policy.BaseBackoff = 200 * time.Millisecond
+		}
+
+		if policy.MaxBackoff <= 0 {
+			policy.MaxBackoff = 5 * time.Second
+		}
+
+		return RequesterFunc(func(ctx context.Context, method, url, apiKey, secretKey string, header http.Header, request interface{}, response interface{}) (int, error) {
+			safe := isSafeRequest(ctx, method, header)
+
+			for attempt := 1; ; attempt++ {
+				code, err := next.Call(withAttempt(ctx, attempt), method, url, apiKey, secretKey, header, request, response)
+				if err == nil || !safe || attempt >= policy.MaxAttempts || ctx.Err() != nil {
+					return code, err
+				}
+
+				var retryAfter time.Duration
+				var apiErr *APIError
+				switch {
+				case errors.As(err, &apiErr):
+					if !policy.retryableCode(apiErr.StatusCode) {
+						return code, err
+					}
+					retryAfter = parseRetryAfter(apiErr.Header)
+				case !errors.Is(err, ErrInternal):
+					return code, err
+				}
+
+				delay := policy.backoff(attempt, retryAfter)
+
+				// No point waiting if the next attempt can't be made
+				// before the deadline.
+				if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+					return code, err
+				}
+
+				if logger != nil {
+					logger.Info("%s %s retrying in %s (attempt %d/%d): %s", method, url, delay, attempt+1, policy.MaxAttempts, err.Error())
+				}
+
+				if sleep(ctx, delay) != nil {
+					return code, err
+				}
+			}
+		})
+	}
+}
+
+type idempotentKey struct{}
+
+// withIdempotent to mark the request as idempotent so it can be
+// retried safely regardless of the http method.
+func withIdempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotentKey{}, true)
+}
+
+func isSafeRequest(ctx context.Context, method string, header http.Header) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+
+	if header != nil && header.Get("Idempotency-Key") != "" {
+		return true
+	}
+
+	idempotent, _ := ctx.Value(idempotentKey{}).(bool)
+	return idempotent
+}
+
+func parseRetryAfter(header http.Header) time.Duration {
+	if header == nil {
+		return 0
+	}
+
+	v := header.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+
+	if s, err := strconv.Atoi(v); err == nil && s > 0 {
+		return time.Duration(s) * time.Second
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t)
+	}
+
+	return 0
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
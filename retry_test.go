@@ -0,0 +1,101 @@
+package xfers_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/rl404/xfers-go"
+)
+
+func TestRetryMiddleware(t *testing.T) {
+	policy := xfers.RetryPolicy{
+		MaxAttempts:          3,
+		BaseBackoff:          time.Millisecond,
+		MaxBackoff:           10 * time.Millisecond,
+		RetryableStatusCodes: []int{http.StatusServiceUnavailable},
+	}
+
+	apiError := func(code int, retryAfter string) error {
+		return &xfers.APIError{StatusCode: code, Header: http.Header{"Retry-After": []string{retryAfter}}}
+	}
+
+	tests := []struct {
+		name      string
+		method    string
+		header    http.Header
+		err       error
+		wantCalls int
+	}{
+		{name: "get internal error", method: http.MethodGet, err: xfers.ErrInternal, wantCalls: 3},
+		{name: "get retryable code", method: http.MethodGet, err: apiError(http.StatusServiceUnavailable, ""), wantCalls: 3},
+		{name: "get non retryable code", method: http.MethodGet, err: apiError(http.StatusBadRequest, ""), wantCalls: 1},
+		{name: "get other error", method: http.MethodGet, err: errors.New("invalid response"), wantCalls: 1},
+		{name: "get encode error", method: http.MethodGet, err: xfers.ErrEncodeRequest, wantCalls: 1},
+		{name: "post idempotency key encode error", method: http.MethodPost, header: http.Header{"Idempotency-Key": []string{"k"}}, err: xfers.ErrEncodeRequest, wantCalls: 1},
+		{name: "post", method: http.MethodPost, err: xfers.ErrInternal, wantCalls: 1},
+		{name: "post idempotency key", method: http.MethodPost, header: http.Header{"Idempotency-Key": []string{"k"}}, err: xfers.ErrInternal, wantCalls: 3},
+		{name: "long retry after", method: http.MethodGet, err: apiError(http.StatusServiceUnavailable, "3600"), wantCalls: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int
+			requester := xfers.Chain(xfers.RequesterFunc(func(ctx context.Context, method, url, apiKey, secretKey string, header http.Header, request interface{}, response interface{}) (int, error) {
+				calls++
+				return http.StatusInternalServerError, tt.err
+			}), xfers.RetryMiddleware(policy, nil))
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+
+			if _, err := requester.Call(ctx, tt.method, "url", "", "", tt.header, nil, nil); err != tt.err {
+				t.Fatalf("got %v, want %v", err, tt.err)
+			}
+			if calls != tt.wantCalls {
+				t.Fatalf("got %d calls, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRetryMiddlewareDefaultBackoff(t *testing.T) {
+	var times []time.Time
+	requester := xfers.Chain(xfers.RequesterFunc(func(ctx context.Context, method, url, apiKey, secretKey string, header http.Header, request interface{}, response interface{}) (int, error) {
+		times = append(times, time.Now())
+		return 0, fmt.Errorf("%w: timeout", xfers.ErrInternal)
+	}), xfers.RetryMiddleware(xfers.RetryPolicy{MaxAttempts: 2}, nil))
+
+	requester.Call(context.Background(), http.MethodGet, "url", "", "", nil, nil, nil)
+
+	if len(times) != 2 {
+		t.Fatalf("got %d calls, want 2", len(times))
+	}
+	if d := times[1].Sub(times[0]); d < 100*time.Millisecond {
+		t.Fatalf("got backoff %s, want default backoff", d)
+	}
+}
+
+func TestRetryMiddlewareDeadline(t *testing.T) {
+	var calls int
+	requester := xfers.Chain(xfers.RequesterFunc(func(ctx context.Context, method, url, apiKey, secretKey string, header http.Header, request interface{}, response interface{}) (int, error) {
+		calls++
+		return http.StatusServiceUnavailable, &xfers.APIError{
+			StatusCode: http.StatusServiceUnavailable,
+			Header:     http.Header{"Retry-After": []string{"60"}},
+		}
+	}), xfers.RetryMiddleware(xfers.DefaultRetryPolicy(), nil))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	requester.Call(ctx, http.MethodGet, "url", "", "", nil, nil, nil)
+
+	if calls != 1 || time.Since(start) > 40*time.Millisecond {
+		t.Fatalf("got %d calls in %s, want 1 call without waiting", calls, time.Since(start))
+	}
+}
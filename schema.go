@@ -0,0 +1,140 @@
+package xfers
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+)
+
+// SchemaVersion is version of the JSON encoding of the public models
+// (Payment, Disbursement, PaymentMethod, Balance, Bank, BankAccount
+// and the action models). It is bumped when a field is renamed or
+// removed, or an enum value is removed. See SCHEMA.md.
+const SchemaVersion = "1"
+
+// Valid to check if the status is known. Empty status is valid.
+func (s Status) Valid() bool {
+	return s == "" || statuses[s]
+}
+
+// MarshalText to encode status. Unknown status is encoded as is.
+func (s Status) MarshalText() ([]byte, error) {
+	return []byte(s), nil
+}
+
+// UnmarshalText to decode status. Unknown status returns ErrInvalidEnum.
+func (s *Status) UnmarshalText(b []byte) error {
+	return unmarshalEnum(s, b, statuses, "status")
+}
+
+// MarshalJSON to encode status as JSON string.
+func (s Status) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(s)
+}
+
+// UnmarshalJSON to decode status from JSON string.
+func (s *Status) UnmarshalJSON(b []byte) error {
+	return unmarshalEnumJSON(s, b)
+}
+
+// Valid to check if the payment type is known. Empty payment type is valid.
+func (p PaymentType) Valid() bool {
+	return p == "" || paymentTypes[p]
+}
+
+// MarshalText to encode payment type. Unknown type is encoded as is.
+func (p PaymentType) MarshalText() ([]byte, error) {
+	return []byte(p), nil
+}
+
+// UnmarshalText to decode payment type. Unknown type returns ErrInvalidEnum.
+func (p *PaymentType) UnmarshalText(b []byte) error {
+	return unmarshalEnum(p, b, paymentTypes, "payment type")
+}
+
+// MarshalJSON to encode payment type as JSON string.
+func (p PaymentType) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(p)
+}
+
+// UnmarshalJSON to decode payment type from JSON string.
+func (p *PaymentType) UnmarshalJSON(b []byte) error {
+	return unmarshalEnumJSON(p, b)
+}
+
+// Valid to check if the bank code is known. Empty bank code is valid.
+func (c BankCode) Valid() bool {
+	return c == "" || bankCodes[c]
+}
+
+// MarshalText to encode bank code. Unknown code is encoded as is.
+func (c BankCode) MarshalText() ([]byte, error) {
+	return []byte(c), nil
+}
+
+// UnmarshalText to decode bank code. Unknown code returns ErrInvalidEnum.
+func (c *BankCode) UnmarshalText(b []byte) error {
+	return unmarshalEnum(c, b, bankCodes, "bank code")
+}
+
+// MarshalJSON to encode bank code as JSON string.
+func (c BankCode) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(c)
+}
+
+// UnmarshalJSON to decode bank code from JSON string.
+func (c *BankCode) UnmarshalJSON(b []byte) error {
+	return unmarshalEnumJSON(c, b)
+}
+
+// Valid to check if the action is known. Empty action is valid.
+func (a Action) Valid() bool {
+	return a == "" || actions[a]
+}
+
+// MarshalText to encode action. Unknown action is encoded as is.
+func (a Action) MarshalText() ([]byte, error) {
+	return []byte(a), nil
+}
+
+// UnmarshalText to decode action. Unknown action returns ErrInvalidEnum.
+func (a *Action) UnmarshalText(b []byte) error {
+	return unmarshalEnum(a, b, actions, "action")
+}
+
+// MarshalJSON to encode action as JSON string.
+func (a Action) MarshalJSON() ([]byte, error) {
+	return marshalEnumJSON(a)
+}
+
+// UnmarshalJSON to decode action from JSON string.
+func (a *Action) UnmarshalJSON(b []byte) error {
+	return unmarshalEnumJSON(a, b)
+}
+
+// unmarshalEnum to validate and decode enum. Empty value is allowed
+// for optional fields.
+func unmarshalEnum[T ~string](v *T, b []byte, valid map[T]bool, name string) error {
+	if len(b) > 0 && !valid[T(b)] {
+		return fmt.Errorf("%w: %s %q", ErrInvalidEnum, name, string(b))
+	}
+	*v = T(b)
+	return nil
+}
+
+func marshalEnumJSON[T ~string](v T) ([]byte, error) {
+	return json.Marshal(string(v))
+}
+
+func unmarshalEnumJSON(v encoding.TextUnmarshaler, b []byte) error {
+	if string(b) == "null" {
+		return nil
+	}
+
+	var str string
+	if err := json.Unmarshal(b, &str); err != nil {
+		return err
+	}
+
+	return v.UnmarshalText([]byte(str))
+}
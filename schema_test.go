@@ -0,0 +1,63 @@
+package xfers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/rl404/xfers-go"
+)
+
+func TestEnumJSON(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     interface{}
+		json      string
+		decode    interface{}
+		wantValid bool
+	}{
+		{name: "status", value: xfers.StatusPaid, json: `"paid"`, decode: new(xfers.Status), wantValid: true},
+		{name: "unknown status", value: xfers.Status("refunded"), json: `"refunded"`, decode: new(xfers.Status)},
+		{name: "empty status", value: xfers.Status(""), json: `""`, decode: new(xfers.Status), wantValid: true},
+		{name: "bank code", value: xfers.BankBCA, json: `"BCA"`, decode: new(xfers.BankCode), wantValid: true},
+		{name: "unknown bank code", value: xfers.BankCode("NEW_BANK"), json: `"NEW_BANK"`, decode: new(xfers.BankCode)},
+		{name: "payment type", value: xfers.PaymentQRIS, json: `"qris"`, decode: new(xfers.PaymentType), wantValid: true},
+		{name: "action", value: xfers.ActionSettle, json: `"settle"`, decode: new(xfers.Action), wantValid: true},
+		{name: "unknown action", value: xfers.Action("refund"), json: `"refund"`, decode: new(xfers.Action)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal(tt.value)
+			if err != nil {
+				t.Fatalf("marshal: %v", err)
+			}
+			if string(b) != tt.json {
+				t.Fatalf("got %s, want %s", b, tt.json)
+			}
+
+			if valid := tt.value.(interface{ Valid() bool }).Valid(); valid != tt.wantValid {
+				t.Fatalf("got valid %v, want %v", valid, tt.wantValid)
+			}
+
+			err = json.Unmarshal(b, tt.decode)
+			if tt.wantValid && err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+			if !tt.wantValid && !errors.Is(err, xfers.ErrInvalidEnum) {
+				t.Fatalf("got %v, want %v", err, xfers.ErrInvalidEnum)
+			}
+		})
+	}
+}
+
+func TestModelJSONUnknownEnum(t *testing.T) {
+	for _, v := range []interface{}{
+		[]xfers.Bank{{ShortCode: "NEW_BANK"}},
+		xfers.Payment{Status: "refunded"},
+	} {
+		if _, err := json.Marshal(v); err != nil {
+			t.Fatalf("marshal %T: %v", v, err)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package xfers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"time"
+)
+
+// StructuredLogger is Logger that also supports structured
+// attributes. If the client's logger implements it, request
+// logs are emitted with attributes like method, path, status,
+// latency, attempt, reference id and request id.
+type StructuredLogger interface {
+	Logger
+	Log(ctx context.Context, level LogLevel, msg string, attrs ...slog.Attr)
+}
+
+// SlogLogger is Logger backed by slog.Handler.
+type SlogLogger struct {
+	handler slog.Handler
+}
+
+// NewSlogLogger to create logger using slog handler.
+// Use slog.Default().Handler() to use the default slog logger.
+func NewSlogLogger(handler slog.Handler) *SlogLogger {
+	return &SlogLogger{
+		handler: handler,
+	}
+}
+
+func (l LogLevel) slogLevel() slog.Level {
+	switch l {
+	case LogDebug:
+		return slog.LevelDebug
+	case LogInfo:
+		return slog.LevelInfo
+	default:
+		return slog.LevelError
+	}
+}
+
+// Debug to print debug log.
+func (l *SlogLogger) Debug(format string, args ...interface{}) {
+	l.logf(LogDebug, format, args...)
+}
+
+// Info to print info log.
+func (l *SlogLogger) Info(format string, args ...interface{}) {
+	l.logf(LogInfo, format, args...)
+}
+
+// Error to print error log.
+func (l *SlogLogger) Error(format string, args ...interface{}) {
+	l.logf(LogError, format, args...)
+}
+
+// Log to print log with structured attributes.
+func (l *SlogLogger) Log(ctx context.Context, level LogLevel, msg string, attrs ...slog.Attr) {
+	l.log(ctx, 3, level, msg, attrs...)
+}
+
+func (l *SlogLogger) logf(level LogLevel, format string, args ...interface{}) {
+	if !l.handler.Enabled(context.Background(), level.slogLevel()) {
+		return
+	}
+	l.log(context.Background(), 4, level, fmt.Sprintf(format, args...))
+}
+
+// log to print log. Skip is the number of frames to skip so the
+// source points to the caller of the exported method, including
+// runtime.Callers and log itself.
+func (l *SlogLogger) log(ctx context.Context, skip int, level LogLevel, msg string, attrs ...slog.Attr) {
+	if !l.handler.Enabled(ctx, level.slogLevel()) {
+		return
+	}
+
+	var pcs [1]uintptr
+	runtime.Callers(skip, pcs[:])
+
+	r := slog.NewRecord(time.Now(), level.slogLevel(), msg, pcs[0])
+	r.AddAttrs(attrs...)
+
+	_ = l.handler.Handle(ctx, r)
+}
@@ -0,0 +1,47 @@
+package xfers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/rl404/xfers-go"
+)
+
+func TestSlogLoggerSource(t *testing.T) {
+	var buf bytes.Buffer
+	logger := xfers.NewSlogLogger(slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+		AddSource: true,
+		Level:     slog.LevelDebug,
+	}))
+
+	logs := []func(){
+		func() { logger.Debug("debug %d", 1) },
+		func() { logger.Info("info %d", 1) },
+		func() { logger.Error("error %d", 1) },
+		func() { logger.Log(context.Background(), xfers.LogInfo, "structured", slog.Int("n", 1)) },
+	}
+
+	for _, log := range logs {
+		buf.Reset()
+		log()
+
+		var record struct {
+			Msg    string `json:"msg"`
+			Source struct {
+				Function string `json:"function"`
+				File     string `json:"file"`
+			} `json:"source"`
+		}
+		if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+			t.Fatal(err)
+		}
+
+		if !strings.HasPrefix(record.Source.Function, "github.com/rl404/xfers-go_test.TestSlogLoggerSource") {
+			t.Errorf("%s: got source %s, want the caller", record.Msg, record.Source.Function)
+		}
+	}
+}
@@ -0,0 +1,309 @@
+package xfers
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// SpendingPolicy is config for limiting disbursements on client side.
+// Zero value of each limit means no limit. The limits are checked
+// before any http request.
+type SpendingPolicy struct {
+	// Max amount of a single disbursement.
+	MaxAmount Amount
+	// Max total amount of all disbursements.
+	HourlyTotal Amount
+	DailyTotal  Amount
+	// Max total amount and count of disbursements to the same
+	// bank account.
+	AccountHourlyTotal Amount
+	AccountDailyTotal  Amount
+	AccountHourlyCount int
+	AccountDailyCount  int
+	// Only allow disbursement to these banks.
+	AllowedBanks []BankCode
+	// Refuse disbursement to these banks.
+	DeniedBanks []BankCode
+	// Store for the spending records. Default is in-memory store.
+	Store SpendingStore
+}
+
+func (p SpendingPolicy) enabled() bool {
+	return p.MaxAmount.IsPositive() ||
+		p.HourlyTotal.IsPositive() ||
+		p.DailyTotal.IsPositive() ||
+		p.AccountHourlyTotal.IsPositive() ||
+		p.AccountDailyTotal.IsPositive() ||
+		p.AccountHourlyCount > 0 ||
+		p.AccountDailyCount > 0 ||
+		len(p.AllowedBanks) > 0 ||
+		len(p.DeniedBanks) > 0
+}
+
+// PolicyError is returned when disbursement violates the spending
+// policy. It matches ErrPolicyViolation with errors.Is.
+type PolicyError struct {
+	Rule PolicyRule
+	// Limit and current usage in amount or count depending on the rule.
+	Limit        Amount
+	Usage        Amount
+	LimitCount   int
+	UsageCount   int
+	Requested    Amount
+	BankCode     BankCode
+	AccountNo    string
+	WindowPeriod time.Duration
+}
+
+// Error to get error message.
+func (e *PolicyError) Error() string {
+	switch e.Rule {
+	case PolicyBankNotAllowed, PolicyBankDenied:
+		return fmt.Sprintf("spending policy %s: bank %s", e.Rule, e.BankCode)
+	case PolicyAccountHourlyCount, PolicyAccountDailyCount:
+		return fmt.Sprintf("spending policy %s: %d of %d disbursements", e.Rule, e.UsageCount, e.LimitCount)
+	default:
+		return fmt.Sprintf("spending policy %s: limit %s, usage %s, requested %s", e.Rule, e.Limit, e.Usage, e.Requested)
+	}
+}
+
+// Is to match ErrPolicyViolation.
+func (e *PolicyError) Is(target error) bool {
+	return target == ErrPolicyViolation
+}
+
+// SpendingRecord is a recorded disbursement for spending policy.
+type SpendingRecord struct {
+	// Unique id of the reservation. Reference id is not unique
+	// because the same reference id can be sent more than once.
+	ID          string
+	ReferenceID string
+	Amount      Amount
+	Time        time.Time
+}
+
+// SpendingStore is storage for spending records. Key is either
+// "total" or "account:<bank code>:<account no>".
+type SpendingStore interface {
+	// Records to get records of the key since the time.
+	Records(ctx context.Context, key string, since time.Time) ([]SpendingRecord, error)
+	Add(ctx context.Context, key string, record SpendingRecord) error
+	// Remove to remove the record by its id.
+	Remove(ctx context.Context, key string, id string) error
+}
+
+type memorySpendingStore struct {
+	mu      sync.Mutex
+	records map[string][]SpendingRecord
+}
+
+// NewMemorySpendingStore to create in-memory spending store. Records
+// older than 24 hours are dropped.
+func NewMemorySpendingStore() SpendingStore {
+	return &memorySpendingStore{
+		records: make(map[string][]SpendingRecord),
+	}
+}
+
+// Records to get records.
+func (m *memorySpendingStore) Records(ctx context.Context, key string, since time.Time) ([]SpendingRecord, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var res []SpendingRecord
+	for _, r := range m.records[key] {
+		if !r.Time.Before(since) {
+			res = append(res, r)
+		}
+	}
+	return res, nil
+}
+
+// Add to add record.
+func (m *memorySpendingStore) Add(ctx context.Context, key string, record SpendingRecord) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	expired := record.Time.Add(-24 * time.Hour)
+	records := m.records[key][:0]
+	for _, r := range m.records[key] {
+		if r.Time.After(expired) {
+			records = append(records, r)
+		}
+	}
+	m.records[key] = append(records, record)
+
+	return nil
+}
+
+// Remove to remove record.
+func (m *memorySpendingStore) Remove(ctx context.Context, key string, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	records := m.records[key][:0]
+	for _, r := range m.records[key] {
+		if r.ID != id {
+			records = append(records, r)
+		}
+	}
+	m.records[key] = records
+
+	return nil
+}
+
+type spendingGuard struct {
+	policy SpendingPolicy
+	// Only guards check and record in this process.
+	mu sync.Mutex
+}
+
+func newSpendingGuard(policy SpendingPolicy) *spendingGuard {
+	if !policy.enabled() {
+		return nil
+	}
+
+	if policy.Store == nil {
+		policy.Store = NewMemorySpendingStore()
+	}
+
+	return &spendingGuard{policy: policy}
+}
+
+// reserveSpending to check the spending policy and record the
+// disbursement. The returned release func should be called with false
+// if the disbursement is surely not created so only this reservation
+// is removed.
+func (c *Client) reserveSpending(ctx context.Context, request CreateDisbursementRequest) (func(created bool), int, error) {
+	g := c.spendingGuard
+	if g == nil {
+		return func(bool) {}, http.StatusOK, nil
+	}
+
+	p := g.policy
+
+	if err := p.checkBank(request); err != nil {
+		return nil, http.StatusForbidden, err
+	}
+
+	if p.MaxAmount.IsPositive() && request.Amount.Cmp(p.MaxAmount) > 0 {
+		return nil, http.StatusForbidden, &PolicyError{
+			Rule:      PolicyMaxAmount,
+			Limit:     p.MaxAmount,
+			Requested: request.Amount,
+		}
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := time.Now()
+	accountKey := fmt.Sprintf("account:%s:%s", request.BankShortCode, request.BankAccountNo)
+
+	for _, l := range []struct {
+		key    string
+		period time.Duration
+		total  Amount
+		count  int
+		rule   PolicyRule
+	}{
+		{key: "total", period: time.Hour, total: p.HourlyTotal, rule: PolicyHourlyTotal},
+		{key: "total", period: 24 * time.Hour, total: p.DailyTotal, rule: PolicyDailyTotal},
+		{key: accountKey, period: time.Hour, total: p.AccountHourlyTotal, rule: PolicyAccountHourlyTotal},
+		{key: accountKey, period: 24 * time.Hour, total: p.AccountDailyTotal, rule: PolicyAccountDailyTotal},
+		{key: accountKey, period: time.Hour, count: p.AccountHourlyCount, rule: PolicyAccountHourlyCount},
+		{key: accountKey, period: 24 * time.Hour, count: p.AccountDailyCount, rule: PolicyAccountDailyCount},
+	} {
+		if !l.total.IsPositive() && l.count <= 0 {
+			continue
+		}
+
+		records, err := p.Store.Records(ctx, l.key, now.Add(-l.period))
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+
+		var usage Amount
+		for _, r := range records {
+			usage = usage.Add(r.Amount)
+		}
+
+		if l.total.IsPositive() && usage.Add(request.Amount).Cmp(l.total) > 0 {
+			return nil, http.StatusForbidden, &PolicyError{
+				Rule:         l.rule,
+				Limit:        l.total,
+				Usage:        usage,
+				Requested:    request.Amount,
+				BankCode:     request.BankShortCode,
+				AccountNo:    request.BankAccountNo,
+				WindowPeriod: l.period,
+			}
+		}
+
+		if l.count > 0 && len(records)+1 > l.count {
+			return nil, http.StatusForbidden, &PolicyError{
+				Rule:         l.rule,
+				LimitCount:   l.count,
+				UsageCount:   len(records),
+				Requested:    request.Amount,
+				BankCode:     request.BankShortCode,
+				AccountNo:    request.BankAccountNo,
+				WindowPeriod: l.period,
+			}
+		}
+	}
+
+	record := SpendingRecord{
+		ID:          rand.Text(),
+		ReferenceID: request.ReferenceID,
+		Amount:      request.Amount,
+		Time:        now,
+	}
+
+	var keys []string
+	remove := func() {
+		for _, k := range keys {
+			if err := p.Store.Remove(context.WithoutCancel(ctx), k, record.ID); err != nil {
+				c.logger.Error("spending store remove %s: %s", record.ReferenceID, err.Error())
+			}
+		}
+	}
+
+	for _, k := range []string{"total", accountKey} {
+		if err := p.Store.Add(ctx, k, record); err != nil {
+			remove()
+			return nil, http.StatusInternalServerError, err
+		}
+		keys = append(keys, k)
+	}
+
+	return func(created bool) {
+		if !created {
+			remove()
+		}
+	}, http.StatusOK, nil
+}
+
+func (p SpendingPolicy) checkBank(request CreateDisbursementRequest) error {
+	for _, b := range p.DeniedBanks {
+		if b == request.BankShortCode {
+			return &PolicyError{Rule: PolicyBankDenied, BankCode: request.BankShortCode, Requested: request.Amount}
+		}
+	}
+
+	if len(p.AllowedBanks) == 0 {
+		return nil
+	}
+
+	for _, b := range p.AllowedBanks {
+		if b == request.BankShortCode {
+			return nil
+		}
+	}
+
+	return &PolicyError{Rule: PolicyBankNotAllowed, BankCode: request.BankShortCode, Requested: request.Amount}
+}
@@ -0,0 +1,243 @@
+package xfers_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/rl404/xfers-go"
+	"github.com/rl404/xfers-go/xferstest"
+)
+
+func newTestClient(t *testing.T, option xfers.Option) (*xfers.Client, *xferstest.Server) {
+	t.Helper()
+
+	srv := xferstest.NewServer("api-key", "secret-key")
+	t.Cleanup(srv.Close)
+
+	if err := srv.SetBalance("10000000"); err != nil {
+		t.Fatal(err)
+	}
+
+	option.APIKey = "api-key"
+	option.SecretKey = "secret-key"
+	option.BaseURL = srv.URL
+	option.Env = xfers.Sandbox
+
+	return xfers.New(option), srv
+}
+
+func disbursementRequest(referenceID string, rupiah int64) xfers.CreateDisbursementRequest {
+	return xfers.CreateDisbursementRequest{
+		ReferenceID:           referenceID,
+		Type:                  xfers.DisbursementBankTransfer,
+		BankAccountHolderName: "Budi",
+		BankAccountNo:         "1234567890",
+		BankShortCode:         xfers.BankBCA,
+		Amount:                xfers.NewAmount(rupiah),
+	}
+}
+
+func TestSpendingDailyTotal(t *testing.T) {
+	client, _ := newTestClient(t, xfers.Option{
+		Spending: xfers.SpendingPolicy{DailyTotal: xfers.NewAmount(250000)},
+	})
+
+	steps := []struct {
+		referenceID string
+		amount      int64
+		wantErr     error
+		duplicate   bool
+	}{
+		{referenceID: "r1", amount: 100000},
+		{referenceID: "r2", amount: 100000},
+		{referenceID: "r2", amount: 10000, duplicate: true},
+		{referenceID: "r3", amount: 100000, wantErr: xfers.ErrPolicyViolation},
+		{referenceID: "r4", amount: 40000},
+		{referenceID: "r5", amount: 1, wantErr: xfers.ErrPolicyViolation},
+	}
+
+	for i, s := range steps {
+		_, _, err := client.CreateDisbursement(disbursementRequest(s.referenceID, s.amount))
+		switch {
+		case s.duplicate:
+			if !xfers.IsDuplicateReference(err) {
+				t.Fatalf("step %d: got %v, want duplicate reference", i, err)
+			}
+		case s.wantErr != nil:
+			if !errors.Is(err, s.wantErr) {
+				t.Fatalf("step %d: got %v, want %v", i, err, s.wantErr)
+			}
+		case err != nil:
+			t.Fatalf("step %d: unexpected error %v", i, err)
+		}
+	}
+}
+
+func TestSpendingReleaseRejected(t *testing.T) {
+	client, srv := newTestClient(t, xfers.Option{
+		Spending: xfers.SpendingPolicy{AccountDailyCount: 2},
+	})
+
+	// Rejected by xfers so the reservation is released.
+	if err := srv.SetBalance("1000"); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := client.CreateDisbursement(disbursementRequest("r1", 5000)); !xfers.IsInsufficientBalance(err) {
+		t.Fatalf("got %v, want insufficient balance", err)
+	}
+
+	if err := srv.SetBalance("10000000"); err != nil {
+		t.Fatal(err)
+	}
+	for i := 2; i <= 3; i++ {
+		if _, _, err := client.CreateDisbursement(disbursementRequest(fmt.Sprintf("r%d", i), 5000)); err != nil {
+			t.Fatalf("r%d: %v", i, err)
+		}
+	}
+
+	if _, _, err := client.CreateDisbursement(disbursementRequest("r4", 5000)); !errors.Is(err, xfers.ErrPolicyViolation) {
+		t.Fatalf("got %v, want policy violation", err)
+	}
+}
+
+func TestSpendingReservation(t *testing.T) {
+	tests := []struct {
+		name        string
+		option      xfers.Option
+		run         func(t *testing.T, client *xfers.Client, srv *xferstest.Server)
+		wantRecords int
+	}{
+		{
+			name: "created",
+			run: func(t *testing.T, client *xfers.Client, srv *xferstest.Server) {
+				if _, _, err := client.CreateDisbursement(disbursementRequest("r1", 5000)); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantRecords: 1,
+		},
+		{
+			name:   "transport error",
+			option: xfers.Option{Middlewares: []xfers.Middleware{failPost(errTimeout)}},
+			run: func(t *testing.T, client *xfers.Client, srv *xferstest.Server) {
+				if _, _, err := client.CreateDisbursement(disbursementRequest("r1", 5000)); !errors.Is(err, errTimeout) {
+					t.Fatalf("got %v, want %v", err, errTimeout)
+				}
+			},
+			wantRecords: 1,
+		},
+		{
+			name:   "5xx",
+			option: xfers.Option{Middlewares: []xfers.Middleware{failPost(&xfers.APIError{StatusCode: http.StatusBadGateway})}},
+			run: func(t *testing.T, client *xfers.Client, srv *xferstest.Server) {
+				if _, _, err := client.CreateDisbursement(disbursementRequest("r1", 5000)); err == nil {
+					t.Fatal("got nil, want error")
+				}
+			},
+			wantRecords: 1,
+		},
+		{
+			name:   "4xx",
+			option: xfers.Option{Middlewares: []xfers.Middleware{failPost(&xfers.APIError{StatusCode: http.StatusBadRequest})}},
+			run: func(t *testing.T, client *xfers.Client, srv *xferstest.Server) {
+				if _, _, err := client.CreateDisbursement(disbursementRequest("r1", 5000)); err == nil {
+					t.Fatal("got nil, want error")
+				}
+			},
+		},
+		{
+			name: "duplicate reference",
+			run: func(t *testing.T, client *xfers.Client, srv *xferstest.Server) {
+				if _, _, err := seedClient(srv).CreateDisbursement(disbursementRequest("r1", 100000)); err != nil {
+					t.Fatal(err)
+				}
+				if _, _, err := client.CreateDisbursement(disbursementRequest("r1", 5000)); !xfers.IsDuplicateReference(err) {
+					t.Fatalf("got %v, want duplicate reference", err)
+				}
+			},
+			wantRecords: 1,
+		},
+		{
+			name:   "existing",
+			option: xfers.Option{Idempotency: xfers.IdempotencyPolicy{MaxAttempts: 2}},
+			run: func(t *testing.T, client *xfers.Client, srv *xferstest.Server) {
+				if _, _, err := seedClient(srv).CreateDisbursement(disbursementRequest("r1", 5000)); err != nil {
+					t.Fatal(err)
+				}
+				if _, _, err := client.CreateDisbursement(disbursementRequest("r1", 5000)); err != nil {
+					t.Fatal(err)
+				}
+			},
+		},
+		{
+			name: "circuit open",
+			option: xfers.Option{
+				Requester: xfers.RequesterFunc(func(ctx context.Context, method, url, apiKey, secretKey string, header http.Header, request interface{}, response interface{}) (int, error) {
+					return http.StatusInternalServerError, errTimeout
+				}),
+				WriteCircuitBreaker: xfers.CircuitBreakerPolicy{FailureThreshold: 1},
+			},
+			run: func(t *testing.T, client *xfers.Client, srv *xferstest.Server) {
+				// Kept because the first request is ambiguous.
+				if _, _, err := client.CreateDisbursement(disbursementRequest("r1", 5000)); !errors.Is(err, errTimeout) {
+					t.Fatalf("got %v, want %v", err, errTimeout)
+				}
+				if _, _, err := client.CreateDisbursement(disbursementRequest("r2", 5000)); !errors.Is(err, xfers.ErrCircuitOpen) {
+					t.Fatalf("got %v, want %v", err, xfers.ErrCircuitOpen)
+				}
+			},
+			wantRecords: 1,
+		},
+		{
+			name:   "rate limit cancelled",
+			option: xfers.Option{RateLimit: xfers.RateLimitPolicy{Rate: 1.0 / 3600, Burst: 1}},
+			run: func(t *testing.T, client *xfers.Client, srv *xferstest.Server) {
+				if _, _, err := client.CreateDisbursement(disbursementRequest("r1", 5000)); err != nil {
+					t.Fatal(err)
+				}
+
+				ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+				defer cancel()
+
+				if _, _, err := client.CreateDisbursementWithContext(ctx, disbursementRequest("r2", 5000)); !errors.Is(err, context.DeadlineExceeded) {
+					t.Fatalf("got %v, want %v", err, context.DeadlineExceeded)
+				}
+			},
+			wantRecords: 1,
+		},
+		{
+			name:   "verification failed",
+			option: xfers.Option{AccountVerification: xfers.AccountVerificationPolicy{Mode: xfers.VerifyReject}},
+			run: func(t *testing.T, client *xfers.Client, srv *xferstest.Server) {
+				srv.AddBankAccount("BCA", "1234567890", "Siti Rahayu")
+
+				var mErr *xfers.NameMismatchError
+				if _, _, err := client.CreateDisbursement(disbursementRequest("r1", 5000)); !errors.As(err, &mErr) {
+					t.Fatalf("got %v, want name mismatch", err)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := xfers.NewMemorySpendingStore()
+			tt.option.Spending = xfers.SpendingPolicy{DailyTotal: xfers.NewAmount(1000000), Store: store}
+
+			client, srv := newTestClient(t, tt.option)
+			tt.run(t, client, srv)
+
+			records, err := store.Records(context.Background(), "total", time.Time{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(records) != tt.wantRecords {
+				t.Fatalf("got %d reservations, want %d", len(records), tt.wantRecords)
+			}
+		})
+	}
+}
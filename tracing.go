@@ -0,0 +1,112 @@
+package xfers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/rl404/xfers-go"
+
+// Span attribute keys.
+const (
+	attrOperation      = attribute.Key("xfers.operation")
+	attrResourceID     = attribute.Key("xfers.resource_id")
+	attrReferenceID    = attribute.Key("xfers.reference_id")
+	attrPaymentType    = attribute.Key("xfers.payment_type")
+	attrBankCode       = attribute.Key("xfers.bank_code")
+	attrStatus         = attribute.Key("xfers.status")
+	attrErrorCode      = attribute.Key("xfers.error_code")
+	attrRequestID      = attribute.Key("xfers.request_id")
+	attrNameMatchScore = attribute.Key("xfers.name_match_score")
+	attrHTTPMethod     = attribute.Key("http.request.method")
+	attrHTTPStatus     = attribute.Key("http.response.status_code")
+)
+
+func newTracer(provider trace.TracerProvider) trace.Tracer {
+	if provider == nil {
+		provider = otel.GetTracerProvider()
+	}
+	return provider.Tracer(tracerName)
+}
+
+// startSpan to start span named after the operation and put
+// the operation name in the context.
+func (c *Client) startSpan(ctx context.Context, op Operation, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	ctx = withOperation(ctx, op)
+	return c.tracer.Start(ctx, "xfers."+string(op),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(append(attrs, attrOperation.String(string(op)))...),
+	)
+}
+
+// setSpanAttributes to annotate the current span in the context.
+func setSpanAttributes(ctx context.Context, attrs ...attribute.KeyValue) {
+	trace.SpanFromContext(ctx).SetAttributes(attrs...)
+}
+
+// TracingMiddleware to create middleware recording the http status
+// and error to the current span and propagating the trace context
+// in the request header. Nil propagator means the global propagator.
+func TracingMiddleware(propagator propagation.TextMapPropagator) Middleware {
+	if propagator == nil {
+		propagator = otel.GetTextMapPropagator()
+	}
+
+	return func(next Requester) Requester {
+		return RequesterFunc(func(ctx context.Context, method, url, apiKey, secretKey string, header http.Header, request interface{}, response interface{}) (int, error) {
+			if header == nil {
+				header = make(http.Header)
+			} else {
+				header = header.Clone()
+			}
+
+			propagator.Inject(ctx, propagation.HeaderCarrier(header))
+
+			code, err := next.Call(ctx, method, url, apiKey, secretKey, header, request, response)
+
+			span := trace.SpanFromContext(ctx)
+			span.SetAttributes(attrHTTPMethod.String(method), attrHTTPStatus.Int(code))
+
+			if err != nil {
+				recordError(span, err)
+			}
+
+			return code, err
+		})
+	}
+}
+
+func recordError(span trace.Span, err error) {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+
+	errCodes := make([]string, len(apiErr.Errors))
+	for i, e := range apiErr.Errors {
+		errCodes[i] = e.Code
+		span.AddEvent("xfers.error", trace.WithAttributes(
+			attribute.String("code", e.Code),
+			attribute.String("title", e.Title),
+			attribute.String("detail", e.Detail),
+		))
+	}
+
+	span.SetAttributes(attrErrorCode.String(strings.Join(errCodes, ",")))
+	if apiErr.RequestID != "" {
+		span.SetAttributes(attrRequestID.String(apiErr.RequestID))
+	}
+
+	span.RecordError(err)
+	span.SetStatus(codes.Error, apiErr.Error())
+}
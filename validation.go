@@ -13,6 +13,176 @@ import (
 var mod *mold.Transformer
 var val *validator.Validate
 
+var statuses = map[Status]bool{
+	StatusPending:    true,
+	StatusProcessing: true,
+	StatusCancelled:  true,
+	StatusExpired:    true,
+	StatusFailed:     true,
+	StatusPaid:       true,
+	StatusCompleted:  true,
+}
+
+var paymentTypes = map[PaymentType]bool{
+	PaymentVA:      true,
+	PaymentOutlet:  true,
+	PaymentEWallet: true,
+	PaymentQRIS:    true,
+}
+
+var bankCodes = map[BankCode]bool{
+	BankBCA:                          true,
+	BankMandiri:                      true,
+	BankBNI:                          true,
+	BankPermata:                      true,
+	BankBRI:                          true,
+	BankCIMB:                         true,
+	BankDanamon:                      true,
+	BankPanin:                        true,
+	BankMaybank:                      true,
+	BankAnglomas:                     true,
+	BankBangkok:                      true,
+	BankAgris:                        true,
+	BankSinarmas:                     true,
+	BankAgroniaga:                    true,
+	BankAndara:                       true,
+	BankAntarDaerah:                  true,
+	BankANZ:                          true,
+	BankArtha:                        true,
+	BankArtos:                        true,
+	BankBisnis:                       true,
+	BankBJB:                          true,
+	BankBNP:                          true,
+	BankBukopin:                      true,
+	BankBumiArta:                     true,
+	BankCapital:                      true,
+	BankBCASyariah:                   true,
+	BankChinatrus:                    true,
+	BankCIMBUSS:                      true,
+	BankCommonwealth:                 true,
+	BankDanamonUUS:                   true,
+	BankDBS:                          true,
+	BankDinar:                        true,
+	BankDKI:                          true,
+	BankDKIUSS:                       true,
+	BankEkonomi:                      true,
+	BankFama:                         true,
+	BankGanesha:                      true,
+	BankHana:                         true,
+	BankHarda:                        true,
+	BankHimpunanSaudara:              true,
+	BankICBC:                         true,
+	BankInaPerdana:                   true,
+	BankIndexSelindo:                 true,
+	BankJasaJakarta:                  true,
+	BankKesejahteraanEkonomi:         true,
+	BankMaspion:                      true,
+	BankMayapada:                     true,
+	BankMaybankSyariah:               true,
+	BankMayora:                       true,
+	BankMega:                         true,
+	BankMestikaDharma:                true,
+	BankMetroExpress:                 true,
+	BankMizuho:                       true,
+	BankMNC:                          true,
+	BankMuamalat:                     true,
+	BankMultiArtaSentosa:             true,
+	BankMutiara:                      true,
+	BankNationalnobu:                 true,
+	BankNusantaraParahyangan:         true,
+	BankOCBC:                         true,
+	BankOCBCUUS:                      true,
+	BankBAML:                         true,
+	BankBOC:                          true,
+	BankIndia:                        true,
+	BankTokyo:                        true,
+	BankPaninSyariah:                 true,
+	BankPermataUUS:                   true,
+	BankPundi:                        true,
+	BankQNBKesawan:                   true,
+	BankRabobank:                     true,
+	BankResona:                       true,
+	BankRoyal:                        true,
+	BankSahabatPurbaDanarta:          true,
+	BankSahabatSampoerna:             true,
+	BankSBI:                          true,
+	BankSinarHarapanBali:             true,
+	BankMitsui:                       true,
+	BankBRISyariah:                   true,
+	BankBukopinSyariah:               true,
+	BankMandiriSyariah:               true,
+	BankMegaSyariah:                  true,
+	BankBTN:                          true,
+	BankBTNUUS:                       true,
+	BankTabunganPensiunanNasional:    true,
+	BankTabunganPensiunanNasionalUUS: true,
+	BankUOB:                          true,
+	BankVictoria:                     true,
+	BankVictoriaSyariah:              true,
+	BankWindu:                        true,
+	BankWoori:                        true,
+	BankYudhaBhakti:                  true,
+	BankAceh:                         true,
+	BankAcehUUS:                      true,
+	BankBali:                         true,
+	BankBengkulu:                     true,
+	BankBPDDIY:                       true,
+	BankBPDDIYSyariah:                true,
+	BankJambi:                        true,
+	BankJambiUUS:                     true,
+	BankJawaTengah:                   true,
+	BankJawaTengahUUS:                true,
+	BankJawaTimur:                    true,
+	BankJawaTimurUUS:                 true,
+	BankKalimantanBarat:              true,
+	BankKalimantanBaratUUS:           true,
+	BankKalimantanSelatan:            true,
+	BankKalimantanSelatanUUS:         true,
+	BankKalimatanTengah:              true,
+	BankKalimatanTimur:               true,
+	BankKalimantanTimurUUS:           true,
+	BankLampung:                      true,
+	BankMaluku:                       true,
+	BankNusaTenggaraBarat:            true,
+	BankNusaTenggaraBaratUUS:         true,
+	BankNusaTenggaraTimur:            true,
+	BankPapua:                        true,
+	BankRiauKepri:                    true,
+	BankRiaouKepriUUS:                true,
+	BankSulawesi:                     true,
+	BankSulawesiTenggara:             true,
+	BankSulselbar:                    true,
+	BankSulselbarUUS:                 true,
+	BankSulut:                        true,
+	BankSumateraBarat:                true,
+	BankSumateraBaratUUS:             true,
+	BankSumselBabel:                  true,
+	BankSumselBabelUUS:               true,
+	BankSumut:                        true,
+	BankSumutUUS:                     true,
+	BankCentratama:                   true,
+	BankCitibank:                     true,
+	BankDeutsche:                     true,
+	BankHSBC:                         true,
+	BankHSBCUUS:                      true,
+	BankJPMorgan:                     true,
+	BankPrimaMaster:                  true,
+	BankStandardCharted:              true,
+	BankMitraNiaga:                   true,
+	BankEkspor:                       true,
+	BankArtaNiagaKencana:             true,
+	BankBJBSyariah:                   true,
+	BankBNISyariah:                   true,
+}
+
+var actions = map[Action]bool{
+	ActionCancel:         true,
+	ActionReceivePayment: true,
+	ActionSettle:         true,
+	ActionComplete:       true,
+	ActionFail:           true,
+}
+
 func init() {
 	val = validator.New()
 	val.RegisterValidationCtx("bank_code", validateBankCode)
@@ -26,6 +196,7 @@ func init() {
 	val.RegisterValidationCtx("va_bank_code", validationVABankCode)
 	val.RegisterValidationCtx("e_wallet", validationEWallet)
 	val.RegisterValidationCtx("payment_method", validationPaymentMethod)
+	val.RegisterCustomTypeFunc(amountMinor, Amount{})
 
 	mod = modifiers.New()
 	mod.Register("no_space", modNoSpace)
@@ -71,17 +242,18 @@ func validate(data interface{}) error {
 	return nil
 }
 
+// amountMinor to validate amount as its minor unit so
+// numeric tags like gt and gte can be used.
+func amountMinor(field reflect.Value) interface{} {
+	if a, ok := field.Interface().(Amount); ok {
+		return a.minor
+	}
+	return nil
+}
+
 func validateStatus(ctx context.Context, fl validator.FieldLevel) bool {
-	return map[Status]bool{
-		"":               true,
-		StatusPending:    true,
-		StatusProcessing: true,
-		StatusCancelled:  true,
-		StatusExpired:    true,
-		StatusFailed:     true,
-		StatusPaid:       true,
-		StatusCompleted:  true,
-	}[Status(fl.Field().String())]
+	s := Status(fl.Field().String())
+	return s == "" || statuses[s]
 }
 
 func validationPaymentAction(ctx context.Context, fl validator.FieldLevel) bool {
@@ -106,12 +278,7 @@ func validationPaymentMethodAction(ctx context.Context, fl validator.FieldLevel)
 }
 
 func validationPaymentType(ctx context.Context, fl validator.FieldLevel) bool {
-	return map[PaymentType]bool{
-		PaymentVA:      true,
-		PaymentOutlet:  true,
-		PaymentEWallet: true,
-		PaymentQRIS:    true,
-	}[PaymentType(fl.Field().String())]
+	return paymentTypes[PaymentType(fl.Field().String())]
 }
 
 func validationDisbursementType(ctx context.Context, fl validator.FieldLevel) bool {
@@ -155,148 +322,5 @@ func validationEWallet(ctx context.Context, fl validator.FieldLevel) bool {
 }
 
 func validateBankCode(ctx context.Context, fl validator.FieldLevel) bool {
-	return map[BankCode]bool{
-		BankBCA:                          true,
-		BankMandiri:                      true,
-		BankBNI:                          true,
-		BankPermata:                      true,
-		BankBRI:                          true,
-		BankCIMB:                         true,
-		BankDanamon:                      true,
-		BankPanin:                        true,
-		BankMaybank:                      true,
-		BankAnglomas:                     true,
-		BankBangkok:                      true,
-		BankAgris:                        true,
-		BankSinarmas:                     true,
-		BankAgroniaga:                    true,
-		BankAndara:                       true,
-		BankAntarDaerah:                  true,
-		BankANZ:                          true,
-		BankArtha:                        true,
-		BankArtos:                        true,
-		BankBisnis:                       true,
-		BankBJB:                          true,
-		BankBNP:                          true,
-		BankBukopin:                      true,
-		BankBumiArta:                     true,
-		BankCapital:                      true,
-		BankBCASyariah:                   true,
-		BankChinatrus:                    true,
-		BankCIMBUSS:                      true,
-		BankCommonwealth:                 true,
-		BankDanamonUUS:                   true,
-		BankDBS:                          true,
-		BankDinar:                        true,
-		BankDKI:                          true,
-		BankDKIUSS:                       true,
-		BankEkonomi:                      true,
-		BankFama:                         true,
-		BankGanesha:                      true,
-		BankHana:                         true,
-		BankHarda:                        true,
-		BankHimpunanSaudara:              true,
-		BankICBC:                         true,
-		BankInaPerdana:                   true,
-		BankIndexSelindo:                 true,
-		BankJasaJakarta:                  true,
-		BankKesejahteraanEkonomi:         true,
-		BankMaspion:                      true,
-		BankMayapada:                     true,
-		BankMaybankSyariah:               true,
-		BankMayora:                       true,
-		BankMega:                         true,
-		BankMestikaDharma:                true,
-		BankMetroExpress:                 true,
-		BankMizuho:                       true,
-		BankMNC:                          true,
-		BankMuamalat:                     true,
-		BankMultiArtaSentosa:             true,
-		BankMutiara:                      true,
-		BankNationalnobu:                 true,
-		BankNusantaraParahyangan:         true,
-		BankOCBC:                         true,
-		BankOCBCUUS:                      true,
-		BankBAML:                         true,
-		BankBOC:                          true,
-		BankIndia:                        true,
-		BankTokyo:                        true,
-		BankPaninSyariah:                 true,
-		BankPermataUUS:                   true,
-		BankPundi:                        true,
-		BankQNBKesawan:                   true,
-		BankRabobank:                     true,
-		BankResona:                       true,
-		BankRoyal:                        true,
-		BankSahabatPurbaDanarta:          true,
-		BankSahabatSampoerna:             true,
-		BankSBI:                          true,
-		BankSinarHarapanBali:             true,
-		BankMitsui:                       true,
-		BankBRISyariah:                   true,
-		BankBukopinSyariah:               true,
-		BankMandiriSyariah:               true,
-		BankMegaSyariah:                  true,
-		BankBTN:                          true,
-		BankBTNUUS:                       true,
-		BankTabunganPensiunanNasional:    true,
-		BankTabunganPensiunanNasionalUUS: true,
-		BankUOB:                          true,
-		BankVictoria:                     true,
-		BankVictoriaSyariah:              true,
-		BankWindu:                        true,
-		BankWoori:                        true,
-		BankYudhaBhakti:                  true,
-		BankAceh:                         true,
-		BankAcehUUS:                      true,
-		BankBali:                         true,
-		BankBengkulu:                     true,
-		BankBPDDIY:                       true,
-		BankBPDDIYSyariah:                true,
-		BankJambi:                        true,
-		BankJambiUUS:                     true,
-		BankJawaTengah:                   true,
-		BankJawaTengahUUS:                true,
-		BankJawaTimur:                    true,
-		BankJawaTimurUUS:                 true,
-		BankKalimantanBarat:              true,
-		BankKalimantanBaratUUS:           true,
-		BankKalimantanSelatan:            true,
-		BankKalimantanSelatanUUS:         true,
-		BankKalimatanTengah:              true,
-		BankKalimatanTimur:               true,
-		BankKalimantanTimurUUS:           true,
-		BankLampung:                      true,
-		BankMaluku:                       true,
-		BankNusaTenggaraBarat:            true,
-		BankNusaTenggaraBaratUUS:         true,
-		BankNusaTenggaraTimur:            true,
-		BankPapua:                        true,
-		BankRiauKepri:                    true,
-		BankRiaouKepriUUS:                true,
-		BankSulawesi:                     true,
-		BankSulawesiTenggara:             true,
-		BankSulselbar:                    true,
-		BankSulselbarUUS:                 true,
-		BankSulut:                        true,
-		BankSumateraBarat:                true,
-		BankSumateraBaratUUS:             true,
-		BankSumselBabel:                  true,
-		BankSumselBabelUUS:               true,
-		BankSumut:                        true,
-		BankSumutUUS:                     true,
-		BankCentratama:                   true,
-		BankCitibank:                     true,
-		BankDeutsche:                     true,
-		BankHSBC:                         true,
-		BankHSBCUUS:                      true,
-		BankJPMorgan:                     true,
-		BankPrimaMaster:                  true,
-		BankStandardCharted:              true,
-		BankMitraNiaga:                   true,
-		BankEkspor:                       true,
-		BankArtaNiagaKencana:             true,
-		BankBJBSyariah:                   true,
-		BankBNISyariah:                   true,
-	}[BankCode(fl.Field().String())]
+	return bankCodes[BankCode(fl.Field().String())]
 }
@@ -0,0 +1,233 @@
+package xfers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// AccountVerificationPolicy is config for verifying bank account before
+// creating disbursement. Zero Mode means disabled.
+type AccountVerificationPolicy struct {
+	// VerifyReject to refuse the disbursement, VerifyFlag to only flag it.
+	Mode VerifyMode
+	// Min name match score (0-1). Default is 0.8.
+	Threshold float64
+	// Called when the name match score is below threshold in
+	// VerifyFlag mode.
+	OnFlag func(ctx context.Context, verification AccountVerification)
+}
+
+// AccountVerification is result of bank account verification.
+type AccountVerification struct {
+	// Name from the request.
+	Name string `json:"name"`
+	// Name from the bank.
+	AccountName string  `json:"accountName"`
+	Score       float64 `json:"score"`
+	Threshold   float64 `json:"threshold"`
+	// True if the score is below threshold.
+	Flagged bool `json:"flagged"`
+}
+
+// NameMismatchError is returned when bank account holder name does not
+// match the name in the request. The names are not included in the
+// error message to keep them out of log.
+type NameMismatchError struct {
+	ReferenceID  string
+	Verification AccountVerification
+}
+
+// Error to get error message.
+func (e *NameMismatchError) Error() string {
+	return fmt.Sprintf("bank account holder name mismatch %s: score %.2f < %.2f",
+		e.ReferenceID,
+		e.Verification.Score,
+		e.Verification.Threshold,
+	)
+}
+
+// VerifyBankAccount to validate bank account and compare the holder name.
+func (c *Client) VerifyBankAccount(ctx context.Context, request ValidateBankAccountRequest, name string, threshold float64) (*AccountVerification, int, error) {
+	if threshold <= 0 {
+		threshold = 0.8
+	}
+
+	account, code, err := c.ValidateBankAccountWithContext(ctx, request)
+	if err != nil {
+		return nil, code, err
+	}
+
+	score := NameMatchScore(name, account.AccountName)
+
+	return &AccountVerification{
+		Name:        name,
+		AccountName: account.AccountName,
+		Score:       score,
+		Threshold:   threshold,
+		Flagged:     score < threshold,
+	}, code, nil
+}
+
+func (c *Client) verifyDisbursement(ctx context.Context, request CreateDisbursementRequest) (*AccountVerification, int, error) {
+	if c.verification.Mode == "" || request.Type != DisbursementBankTransfer {
+		return nil, http.StatusOK, nil
+	}
+
+	v, code, err := c.VerifyBankAccount(ctx, ValidateBankAccountRequest{
+		AccountNo:     request.BankAccountNo,
+		BankShortCode: request.BankShortCode,
+	}, request.BankAccountHolderName, c.verification.Threshold)
+	if err != nil {
+		return nil, code, err
+	}
+
+	setSpanAttributes(ctx, attrNameMatchScore.Float64(v.Score))
+
+	if !v.Flagged {
+		return v, code, nil
+	}
+
+	if c.verification.Mode == VerifyReject {
+		return nil, http.StatusBadRequest, &NameMismatchError{ReferenceID: request.ReferenceID, Verification: *v}
+	}
+
+	c.logger.Info("bank account holder name flagged %s: score %.2f < %.2f", request.ReferenceID, v.Score, v.Threshold)
+	if c.verification.OnFlag != nil {
+		c.verification.OnFlag(ctx, *v)
+	}
+
+	return v, code, nil
+}
+
+// Honorifics, titles and company forms ignored when matching names.
+var nameStopWords = map[string]bool{
+	// Honorifics.
+	"bapak": true, "bpk": true, "bp": true, "pak": true, "ibu": true, "bu": true,
+	"sdr": true, "sdri": true, "saudara": true, "saudari": true,
+	"tn": true, "tuan": true, "ny": true, "nyonya": true, "nn": true, "nona": true,
+	"mr": true, "mrs": true, "ms": true, "miss": true,
+	"h": true, "hj": true, "haji": true, "hajjah": true,
+	// Academic titles.
+	"dr": true, "drs": true, "dra": true, "ir": true, "prof": true,
+	"se": true, "sh": true, "st": true, "mm": true, "mba": true,
+	"skom": true, "spd": true, "sked": true, "amd": true,
+	// Company forms.
+	"pt": true, "cv": true, "tbk": true, "persero": true, "ud": true,
+	"pd": true, "fa": true, "koperasi": true, "yayasan": true,
+}
+
+// normalizeName to lowercase, strip punctuation, honorifics and company
+// forms, and split the name into words.
+func normalizeName(name string) []string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		case r == '.' || r == '\'' || r == '`':
+			// "S.Kom" -> "skom", "Ma'ruf" -> "maruf".
+		default:
+			b.WriteRune(' ')
+		}
+	}
+
+	words := strings.Fields(b.String())
+
+	res := make([]string, 0, len(words))
+	for _, w := range words {
+		if !nameStopWords[w] {
+			res = append(res, w)
+		}
+	}
+
+	// Name consisting of stop words only, e.g. "PT".
+	if len(res) == 0 {
+		return words
+	}
+
+	return res
+}
+
+// NameMatchScore to get similarity score (0-1) of two names. Case,
+// punctuation, word order, Indonesian honorifics, academic titles and
+// company forms (PT, CV, Tbk) are ignored. Initials match the full word.
+func NameMatchScore(a, b string) float64 {
+	wa, wb := normalizeName(a), normalizeName(b)
+	if len(wa) == 0 || len(wb) == 0 {
+		return 0
+	}
+
+	sa, sb := append([]string{}, wa...), append([]string{}, wb...)
+	sort.Strings(sa)
+	sort.Strings(sb)
+
+	// Names written with or without space, e.g. "Nurhaliza" and "Nur Haliza".
+	score := similarity(strings.Join(sa, ""), strings.Join(sb, ""))
+	if s := similarity(strings.Join(wa, ""), strings.Join(wb, "")); s > score {
+		score = s
+	}
+
+	if s := (wordsScore(wa, wb) + wordsScore(wb, wa)) / 2; s > score {
+		score = s
+	}
+
+	return score
+}
+
+// wordsScore to get length weighted average of the best match of each
+// word in a to the words in b.
+func wordsScore(a, b []string) float64 {
+	var total, weight float64
+	for _, wa := range a {
+		best := 0.0
+		for _, wb := range b {
+			if s := wordSimilarity(wa, wb); s > best {
+				best = s
+			}
+		}
+		l := float64(len([]rune(wa)))
+		total += best * l
+		weight += l
+	}
+	return total / weight
+}
+
+func wordSimilarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	if (len(ra) == 1 || len(rb) == 1) && ra[0] == rb[0] {
+		return 1
+	}
+	return similarity(a, b)
+}
+
+// similarity to get levenshtein similarity (0-1).
+func similarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 && len(rb) == 0 {
+		return 1
+	}
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return 1 - float64(prev[len(rb)])/float64(max(len(ra), len(rb)))
+}
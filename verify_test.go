@@ -0,0 +1,50 @@
+package xfers_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/rl404/xfers-go"
+)
+
+func TestNameMatchScore(t *testing.T) {
+	tests := []struct {
+		a, b  string
+		match bool
+	}{
+		{a: "Budi Santoso", b: "BUDI SANTOSO", match: true},
+		{a: "Bpk. Budi Santoso, S.Kom", b: "budi santoso", match: true},
+		{a: "Santoso Budi", b: "Budi Santoso", match: true},
+		{a: "B. Santoso", b: "Budi Santoso", match: true},
+		{a: "PT Maju Jaya Tbk", b: "Maju Jaya", match: true},
+		{a: "Budi Santoso", b: "Siti Rahayu"},
+	}
+
+	for _, tt := range tests {
+		if score := xfers.NameMatchScore(tt.a, tt.b); (score >= 0.8) != tt.match {
+			t.Errorf("%q vs %q: got score %.2f, want match %v", tt.a, tt.b, score, tt.match)
+		}
+	}
+}
+
+func TestVerifyDisbursementReject(t *testing.T) {
+	client, srv := newTestClient(t, xfers.Option{
+		AccountVerification: xfers.AccountVerificationPolicy{Mode: xfers.VerifyReject},
+	})
+	srv.AddBankAccount("BCA", "1234567890", "Siti Rahayu")
+
+	_, code, err := client.CreateDisbursement(disbursementRequest("r1", 100000))
+
+	var mErr *xfers.NameMismatchError
+	if !errors.As(err, &mErr) || code != http.StatusBadRequest {
+		t.Fatalf("got %v %d, want name mismatch", err, code)
+	}
+	if mErr.ReferenceID != "r1" || mErr.Verification.AccountName != "Siti Rahayu" {
+		t.Fatalf("got %+v", mErr)
+	}
+	if msg := err.Error(); strings.Contains(msg, "Budi") || strings.Contains(msg, "Siti") {
+		t.Fatalf("got name in error message %q", msg)
+	}
+}
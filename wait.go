@@ -0,0 +1,124 @@
+package xfers
+
+import (
+	"context"
+	"time"
+)
+
+// WaitOption is config for waiting payment or disbursement
+// until it reaches the expected status.
+type WaitOption struct {
+	// Initial polling interval. Default is 2 seconds.
+	Interval time.Duration
+	// Max polling interval. Default is 30 seconds.
+	MaxInterval time.Duration
+	// Interval multiplier after every poll. Default is 1.5.
+	Multiplier float64
+	// Statuses to stop waiting. Default is the resource's
+	// terminal statuses.
+	Statuses []Status
+	// Called every time the status changes including
+	// the first fetched status.
+	OnStatus func(status Status)
+}
+
+var (
+	paymentTerminalStatuses      = []Status{StatusPaid, StatusCompleted, StatusExpired, StatusCancelled, StatusFailed}
+	disbursementTerminalStatuses = []Status{StatusCompleted, StatusFailed}
+)
+
+func (o *WaitOption) setDefault(statuses []Status) {
+	if o.Interval <= 0 {
+		o.Interval = 2 * time.Second
+	}
+
+	if o.MaxInterval <= 0 {
+		o.MaxInterval = 30 * time.Second
+	}
+
+	if o.Multiplier < 1 {
+		o.Multiplier = 1.5
+	}
+
+	if len(o.Statuses) == 0 {
+		o.Statuses = statuses
+	}
+}
+
+// WaitForPayment to poll payment until it reaches the expected
+// status (paid, completed, expired, cancelled or failed by default)
+// or the context is done.
+//
+// If context is done first, the last fetched payment is returned
+// together with the context error. Errors other than transport
+// errors and 5xx stop the polling.
+func (c *Client) WaitForPayment(ctx context.Context, id string, option WaitOption) (*Payment, int, error) {
+	option.setDefault(paymentTerminalStatuses)
+	return wait(ctx, option, func() (*Payment, int, error) {
+		return c.GetPaymentWithContext(ctx, id)
+	}, func(p *Payment) Status {
+		return p.Status
+	})
+}
+
+// WaitForDisbursement to poll disbursement until it reaches the
+// expected status (completed or failed by default) or the context
+// is done.
+//
+// If context is done first, the last fetched disbursement is returned
+// together with the context error. Errors other than transport
+// errors and 5xx stop the polling.
+func (c *Client) WaitForDisbursement(ctx context.Context, id string, option WaitOption) (*Disbursement, int, error) {
+	option.setDefault(disbursementTerminalStatuses)
+	return wait(ctx, option, func() (*Disbursement, int, error) {
+		return c.GetDisbursementWithContext(ctx, id)
+	}, func(d *Disbursement) Status {
+		return d.Status
+	})
+}
+
+func wait[T any](ctx context.Context, option WaitOption, get func() (*T, int, error), status func(*T) Status) (*T, int, error) {
+	var last *T
+	var lastStatus Status
+	var code int
+
+	interval := option.Interval
+
+	for {
+		res, c, err := get()
+		code = c
+
+		switch {
+		case err == nil:
+			last = res
+			s := status(res)
+
+			if s != lastStatus {
+				lastStatus = s
+				if option.OnStatus != nil {
+					option.OnStatus(s)
+				}
+			}
+
+			for _, st := range option.Statuses {
+				if s == st {
+					return res, code, nil
+				}
+			}
+		case ctx.Err() != nil:
+			return last, code, ctx.Err()
+		case !isAmbiguous(err):
+			// Only transport errors and 5xx are polled again.
+			return last, code, err
+		}
+
+		if err := sleep(ctx, interval); err != nil {
+			return last, code, err
+		}
+
+		interval = time.Duration(float64(interval) * option.Multiplier)
+		if interval > option.MaxInterval {
+			interval = option.MaxInterval
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package xfers_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/rl404/xfers-go"
+)
+
+func TestWaitStopsOnPermanentError(t *testing.T) {
+	timeout := xfers.RequesterFunc(func(ctx context.Context, method, url, apiKey, secretKey string, header http.Header, request interface{}, response interface{}) (int, error) {
+		return http.StatusInternalServerError, errTimeout
+	})
+
+	tests := []struct {
+		name   string
+		option xfers.Option
+		id     string
+		check  func(error) bool
+	}{
+		{name: "empty id", check: func(err error) bool { return err != nil }},
+		{name: "not found", id: "missing", check: xfers.IsNotFound},
+		{
+			name: "circuit open",
+			id:   "d1",
+			option: xfers.Option{
+				Requester:          timeout,
+				ReadCircuitBreaker: xfers.CircuitBreakerPolicy{FailureThreshold: 1, OpenTimeout: time.Hour},
+			},
+			check: func(err error) bool { return errors.Is(err, xfers.ErrCircuitOpen) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newTestClient(t, tt.option)
+
+			done := make(chan error, 1)
+			go func() {
+				_, _, err := client.WaitForDisbursement(context.Background(), tt.id, xfers.WaitOption{Interval: time.Millisecond})
+				done <- err
+			}()
+
+			select {
+			case err := <-done:
+				if !tt.check(err) {
+					t.Fatalf("got %v", err)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("wait did not stop")
+			}
+		})
+	}
+}
+
+func TestWaitRetriesTransportError(t *testing.T) {
+	var calls int
+	client := xfers.New(xfers.Option{
+		Requester: xfers.RequesterFunc(func(ctx context.Context, method, url, apiKey, secretKey string, header http.Header, request interface{}, response interface{}) (int, error) {
+			calls++
+			return http.StatusInternalServerError, errTimeout
+		}),
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, _, err := client.WaitForDisbursement(ctx, "d1", xfers.WaitOption{Interval: time.Millisecond}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want %v", err, context.DeadlineExceeded)
+	}
+	if calls < 2 {
+		t.Fatalf("got %d calls, want polling again", calls)
+	}
+}
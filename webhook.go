@@ -0,0 +1,246 @@
+package xfers
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// ErrWebhookUnverified is error when webhook request fails verification.
+var ErrWebhookUnverified = errors.New("webhook request is not verified")
+
+// WebhookOption is config for webhook handler.
+//
+// Xfers doesn't sign the callbacks, so either Verify or Client
+// must be set. Otherwise, every request is rejected.
+//
+// Request is checked with Verify first if set (e.g. WebhookBasicAuth
+// with credentials in the callback url). If Client is set, the
+// payment or disbursement is fetched by its id and the fetched one
+// is passed to the callbacks instead of the request body, so a forged
+// request can't change the status.
+//
+// Callback returning error will make the handler respond with
+// status 500 so xfers will resend the callback.
+type WebhookOption struct {
+	Verify      func(r *http.Request, body []byte) error
+	Client      *Client
+	MaxBodySize int64
+	Logger      Logger
+
+	// Called for every payment callback before the
+	// status specific callback.
+	OnPayment          func(ctx context.Context, payment Payment) error
+	OnPaymentPaid      func(ctx context.Context, payment Payment) error
+	OnPaymentCompleted func(ctx context.Context, payment Payment) error
+	OnPaymentExpired   func(ctx context.Context, payment Payment) error
+	OnPaymentCancelled func(ctx context.Context, payment Payment) error
+	OnPaymentFailed    func(ctx context.Context, payment Payment) error
+
+	// Called for every disbursement callback before the
+	// status specific callback.
+	OnDisbursement          func(ctx context.Context, disbursement Disbursement) error
+	OnDisbursementCompleted func(ctx context.Context, disbursement Disbursement) error
+	OnDisbursementFailed    func(ctx context.Context, disbursement Disbursement) error
+}
+
+type webhookHandler struct {
+	option WebhookOption
+}
+
+// NewWebhookHandler to create http handler for receiving
+// payment and disbursement status callbacks.
+func NewWebhookHandler(option WebhookOption) http.Handler {
+	if option.MaxBodySize <= 0 {
+		option.MaxBodySize = 1 << 20
+	}
+
+	if option.Logger == nil {
+		option.Logger = defaultLogger(LogError)
+	}
+
+	return &webhookHandler{
+		option: option,
+	}
+}
+
+// WebhookBasicAuth to create webhook verifier using basic auth.
+func WebhookBasicAuth(username, password string) func(r *http.Request, body []byte) error {
+	return func(r *http.Request, body []byte) error {
+		u, p, ok := r.BasicAuth()
+		if !ok ||
+			subtle.ConstantTimeCompare([]byte(u), []byte(username)) != 1 ||
+			subtle.ConstantTimeCompare([]byte(p), []byte(password)) != 1 {
+			return ErrWebhookUnverified
+		}
+		return nil
+	}
+}
+
+func (h *webhookHandler) verify(r *http.Request, body []byte) error {
+	if h.option.Verify == nil && h.option.Client == nil {
+		return ErrWebhookUnverified
+	}
+
+	if h.option.Verify != nil {
+		return h.option.Verify(r, body)
+	}
+
+	return nil
+}
+
+type webhookPayload struct {
+	Data struct {
+		ID         string `json:"id"`
+		Type       string `json:"type"`
+		Attributes struct {
+			DisbursementMethod json.RawMessage `json:"disbursementMethod"`
+		} `json:"attributes"`
+	} `json:"data"`
+}
+
+// ServeHTTP to handle webhook request.
+func (h *webhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, h.option.MaxBodySize))
+	if err != nil {
+		h.option.Logger.Error(err.Error())
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if err := h.verify(r, body); err != nil {
+		h.option.Logger.Error(err.Error())
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	var payload webhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		h.option.Logger.Error(err.Error())
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	switch {
+	case payload.Data.Type == "disbursement" || (payload.Data.Type == "" && len(payload.Data.Attributes.DisbursementMethod) > 0):
+		err = h.handleDisbursement(r.Context(), body)
+	case payload.Data.Type == "payment" || payload.Data.Type == "":
+		err = h.handlePayment(r.Context(), body)
+	default:
+		h.option.Logger.Info("webhook: ignored %s %s", payload.Data.Type, payload.Data.ID)
+	}
+
+	if errors.Is(err, ErrWebhookUnverified) {
+		h.option.Logger.Error(err.Error())
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if err != nil {
+		h.option.Logger.Error(err.Error())
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *webhookHandler) handlePayment(ctx context.Context, body []byte) error {
+	var p document[resource[paymentAttributes]]
+	if err := json.Unmarshal(body, &p); err != nil {
+		return err
+	}
+
+	data := toPayment(p.Data)
+
+	if h.option.Client != nil {
+		if data.ID == "" {
+			return ErrWebhookUnverified
+		}
+
+		fetched, _, err := h.option.Client.GetPaymentWithContext(ctx, data.ID)
+		if err != nil {
+			return h.fetchError(err)
+		}
+		data = *fetched
+	}
+
+	h.option.Logger.Info("webhook: payment %s %s", data.ID, data.Status)
+
+	if h.option.OnPayment != nil {
+		if err := h.option.OnPayment(ctx, data); err != nil {
+			return err
+		}
+	}
+
+	callback := map[Status]func(context.Context, Payment) error{
+		StatusPaid:      h.option.OnPaymentPaid,
+		StatusCompleted: h.option.OnPaymentCompleted,
+		StatusExpired:   h.option.OnPaymentExpired,
+		StatusCancelled: h.option.OnPaymentCancelled,
+		StatusFailed:    h.option.OnPaymentFailed,
+	}[data.Status]
+
+	if callback == nil {
+		return nil
+	}
+
+	return callback(ctx, data)
+}
+
+func (h *webhookHandler) handleDisbursement(ctx context.Context, body []byte) error {
+	var d document[resource[disbursementAttributes]]
+	if err := json.Unmarshal(body, &d); err != nil {
+		return err
+	}
+
+	data := toDisbursement(d.Data)
+
+	if h.option.Client != nil {
+		if data.ID == "" {
+			return ErrWebhookUnverified
+		}
+
+		fetched, _, err := h.option.Client.GetDisbursementWithContext(ctx, data.ID)
+		if err != nil {
+			return h.fetchError(err)
+		}
+		data = *fetched
+	}
+
+	h.option.Logger.Info("webhook: disbursement %s %s", data.ID, data.Status)
+
+	if h.option.OnDisbursement != nil {
+		if err := h.option.OnDisbursement(ctx, data); err != nil {
+			return err
+		}
+	}
+
+	callback := map[Status]func(context.Context, Disbursement) error{
+		StatusCompleted: h.option.OnDisbursementCompleted,
+		StatusFailed:    h.option.OnDisbursementFailed,
+	}[data.Status]
+
+	if callback == nil {
+		return nil
+	}
+
+	return callback(ctx, data)
+}
+
+// fetchError to reject the callback if the resource doesn't exist.
+// Other errors are returned so xfers will resend the callback.
+func (h *webhookHandler) fetchError(err error) error {
+	if IsNotFound(err) {
+		return ErrWebhookUnverified
+	}
+	return err
+}
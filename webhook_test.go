@@ -0,0 +1,173 @@
+package xfers_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rl404/xfers-go"
+)
+
+func webhookRequest(body string) *http.Request {
+	return httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+}
+
+func paymentCallback(id, status string) string {
+	return fmt.Sprintf(`{"data":{"id":%q,"type":"payment","attributes":{"status":%q,"amount":"10000.0"}}}`, id, status)
+}
+
+func TestWebhookVerify(t *testing.T) {
+	tests := []struct {
+		name     string
+		option   xfers.WebhookOption
+		auth     bool
+		wantCode int
+	}{
+		{name: "not configured", wantCode: http.StatusUnauthorized},
+		{name: "basic auth missing", option: xfers.WebhookOption{Verify: xfers.WebhookBasicAuth("user", "pass")}, wantCode: http.StatusUnauthorized},
+		{name: "basic auth", option: xfers.WebhookOption{Verify: xfers.WebhookBasicAuth("user", "pass")}, auth: true, wantCode: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var paid []xfers.Payment
+			tt.option.OnPaymentPaid = func(ctx context.Context, payment xfers.Payment) error {
+				paid = append(paid, payment)
+				return nil
+			}
+
+			r := webhookRequest(paymentCallback("p1", "paid"))
+			if tt.auth {
+				r.SetBasicAuth("user", "pass")
+			}
+
+			w := httptest.NewRecorder()
+			xfers.NewWebhookHandler(tt.option).ServeHTTP(w, r)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("got %d, want %d", w.Code, tt.wantCode)
+			}
+			if wantCalls := map[bool]int{true: 1}[tt.wantCode == http.StatusOK]; len(paid) != wantCalls {
+				t.Fatalf("got %d callbacks, want %d", len(paid), wantCalls)
+			}
+			if len(paid) > 0 && (paid[0].ID != "p1" || paid[0].Amount.Cmp(xfers.NewAmount(10000)) != 0) {
+				t.Fatalf("got %+v", paid[0])
+			}
+		})
+	}
+}
+
+func TestWebhookFetch(t *testing.T) {
+	client, _ := newTestClient(t, xfers.Option{})
+
+	payment, _, err := client.CreatePayment(xfers.CreatePaymentRequest{
+		PaymentMethodType: xfers.PaymentQRIS,
+		Amount:            xfers.NewAmount(10000),
+		ReferenceID:       "p1",
+		ExpiredAt:         time.Now().Add(time.Hour),
+		DisplayName:       "Budi",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	disbursement, _, err := client.CreateDisbursement(disbursementRequest("d1", 10000))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var statuses []xfers.Status
+	var paid int
+	handler := xfers.NewWebhookHandler(xfers.WebhookOption{
+		Client: client,
+		OnPayment: func(ctx context.Context, payment xfers.Payment) error {
+			statuses = append(statuses, payment.Status)
+			return nil
+		},
+		OnPaymentPaid: func(ctx context.Context, payment xfers.Payment) error {
+			paid++
+			return nil
+		},
+		OnDisbursement: func(ctx context.Context, disbursement xfers.Disbursement) error {
+			statuses = append(statuses, disbursement.Status)
+			return nil
+		},
+	})
+
+	tests := []struct {
+		name       string
+		body       string
+		wantCode   int
+		wantStatus xfers.Status
+	}{
+		{
+			name:       "forged payment status",
+			body:       paymentCallback(payment.ID, "paid"),
+			wantCode:   http.StatusOK,
+			wantStatus: payment.Status,
+		},
+		{
+			name:       "disbursement",
+			body:       fmt.Sprintf(`{"data":{"id":%q,"type":"disbursement","attributes":{"status":"completed"}}}`, disbursement.ID),
+			wantCode:   http.StatusOK,
+			wantStatus: disbursement.Status,
+		},
+		{name: "unknown payment", body: paymentCallback("unknown", "paid"), wantCode: http.StatusUnauthorized},
+		{name: "empty id", body: paymentCallback("", "paid"), wantCode: http.StatusUnauthorized},
+		{name: "invalid body", body: "{", wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statuses = nil
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, webhookRequest(tt.body))
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("got %d, want %d", w.Code, tt.wantCode)
+			}
+
+			if tt.wantStatus == "" {
+				if len(statuses) != 0 {
+					t.Fatalf("got %v, want no callback", statuses)
+				}
+				return
+			}
+
+			if len(statuses) != 1 || statuses[0] != tt.wantStatus {
+				t.Fatalf("got %v, want %s", statuses, tt.wantStatus)
+			}
+		})
+	}
+
+	if paid != 0 {
+		t.Fatalf("got %d paid callbacks from forged status, want 0", paid)
+	}
+}
+
+func TestWebhookCallbackError(t *testing.T) {
+	handler := xfers.NewWebhookHandler(xfers.WebhookOption{
+		Verify: func(r *http.Request, body []byte) error { return nil },
+		OnPayment: func(ctx context.Context, payment xfers.Payment) error {
+			return fmt.Errorf("db down")
+		},
+	})
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, webhookRequest(paymentCallback("p1", "paid")))
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("got %d, want %d so xfers resends", w.Code, http.StatusInternalServerError)
+	}
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/webhook", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("got %d, want %d", w.Code, http.StatusMethodNotAllowed)
+	}
+}
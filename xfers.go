@@ -3,26 +3,74 @@ package xfers
 import (
 	"net/http"
 	"time"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Client is xfers client.
 type Client struct {
-	apiKey    string
-	secretKey string
-	baseURL   string
-	env       EnvironmentType
-	requester Requester
-	logger    Logger
+	apiKey      string
+	secretKey   string
+	baseURL     string
+	env         EnvironmentType
+	requester   Requester
+	logger      Logger
+	idempotency IdempotencyPolicy
+	tracer      trace.Tracer
+	metrics     MetricsRecorder
+
+	readBreaker  *CircuitBreaker
+	writeBreaker *CircuitBreaker
+
+	verification  AccountVerificationPolicy
+	balanceGuard  *balanceGuard
+	spendingGuard *spendingGuard
 }
 
 // Option is config for xfers client.
 type Option struct {
-	APIKey    string
-	SecretKey string
-	BaseURL   string
-	Env       EnvironmentType
-	Requester Requester
-	Logger    Logger
+	APIKey      string
+	SecretKey   string
+	BaseURL     string
+	Env         EnvironmentType
+	Requester   Requester
+	Logger      Logger
+	Retry       RetryPolicy
+	Idempotency IdempotencyPolicy
+	// Header and json field names whose values are masked in log.
+	// Nil means DefaultRedactFields().
+	RedactFields []string
+	// Middlewares wrapping the requester. The first one is the
+	// outermost. They are called after tracing and before circuit
+	// breaker, retry, rate limit and metrics, so rate limit and
+	// metrics apply to every retry attempt.
+	Middlewares []Middleware
+	// Tracing provider and propagator. Nil means the global
+	// provider and propagator from otel package.
+	TracerProvider trace.TracerProvider
+	Propagator     propagation.TextMapPropagator
+	// Metrics recorder for every request attempt. Payment and
+	// disbursement creation are also recorded if it implements
+	// BusinessMetricsRecorder. See promxfers package.
+	Metrics MetricsRecorder
+	// Client side rate limit for all requests and per operation.
+	RateLimit           RateLimitPolicy
+	OperationRateLimits map[Operation]RateLimitPolicy
+	// Circuit breakers for read (GET and validating bank account)
+	// and write requests.
+	ReadCircuitBreaker  CircuitBreakerPolicy
+	WriteCircuitBreaker CircuitBreakerPolicy
+	// Http request timeout for the default requester.
+	// Default is 10 seconds.
+	Timeout time.Duration
+	// Validate bank account and match the holder name before
+	// creating bank transfer disbursement.
+	AccountVerification AccountVerificationPolicy
+	// Check available balance before creating disbursement.
+	BalanceGuard BalanceGuardPolicy
+	// Client side disbursement limits.
+	Spending SpendingPolicy
 }
 
 // New to create new xfers client with config.
@@ -31,19 +79,47 @@ func New(option Option) *Client {
 		option.Logger = defaultLogger(LogError)
 	}
 
+	if option.Timeout <= 0 {
+		option.Timeout = 10 * time.Second
+	}
+
 	if option.Requester == nil {
 		option.Requester = defaultRequester(&http.Client{
-			Timeout: 10 * time.Second,
-		}, option.Logger)
+			Timeout: option.Timeout,
+		}, option.Logger, option.RedactFields)
 	}
 
+	readBreaker := NewCircuitBreaker(option.ReadCircuitBreaker)
+	writeBreaker := NewCircuitBreaker(option.WriteCircuitBreaker)
+
+	middlewares := append([]Middleware{TracingMiddleware(option.Propagator)}, option.Middlewares...)
+	middlewares = append(middlewares,
+		CircuitBreakerMiddleware(readBreaker, writeBreaker),
+		RetryMiddleware(option.Retry, option.Logger),
+		RateLimitMiddleware(option.RateLimit, option.OperationRateLimits),
+	)
+	if option.Metrics != nil {
+		middlewares = append(middlewares, MetricsMiddleware(option.Metrics))
+	}
+	option.Requester = Chain(option.Requester, middlewares...)
+
 	return &Client{
-		apiKey:    option.APIKey,
-		secretKey: option.SecretKey,
-		baseURL:   option.BaseURL,
-		requester: option.Requester,
-		logger:    option.Logger,
-		env:       option.Env,
+		apiKey:      option.APIKey,
+		secretKey:   option.SecretKey,
+		baseURL:     option.BaseURL,
+		requester:   option.Requester,
+		logger:      option.Logger,
+		env:         option.Env,
+		idempotency: option.Idempotency,
+		tracer:      newTracer(option.TracerProvider),
+		metrics:     option.Metrics,
+
+		readBreaker:  readBreaker,
+		writeBreaker: writeBreaker,
+
+		verification:  option.AccountVerification,
+		balanceGuard:  newBalanceGuard(option.BalanceGuard),
+		spendingGuard: newSpendingGuard(option.Spending),
 	}
 }
 
@@ -53,10 +129,7 @@ func NewDefault(apiKey string, secretKey string, env EnvironmentType) *Client {
 		APIKey:    apiKey,
 		SecretKey: secretKey,
 		BaseURL:   envURL[env],
-		Requester: defaultRequester(&http.Client{
-			Timeout: 10 * time.Second,
-		}, defaultLogger(envLog[env])),
-		Logger: defaultLogger(envLog[env]),
-		Env:    env,
+		Logger:    defaultLogger(envLog[env]),
+		Env:       env,
 	})
 }
@@ -0,0 +1,190 @@
+package xferstest
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+type disbursement struct {
+	id               string
+	refID            string
+	amount           money
+	fees             money
+	state            string
+	description      string
+	failureReason    string
+	created          time.Time
+	kind             string
+	bankShortCode    string
+	bankName         string
+	accountNo        string
+	holderName       string
+	serverHolderName string
+}
+
+func (d *disbursement) referenceID() string  { return d.refID }
+func (d *disbursement) status() string       { return d.state }
+func (d *disbursement) createdAt() time.Time { return d.created }
+
+func (d *disbursement) resource() map[string]interface{} {
+	return map[string]interface{}{
+		"id":   d.id,
+		"type": "disbursement",
+		"attributes": map[string]interface{}{
+			"referenceId":   d.refID,
+			"description":   d.description,
+			"amount":        d.amount,
+			"status":        d.state,
+			"createdAt":     d.created,
+			"fees":          d.fees,
+			"failureReason": d.failureReason,
+			"disbursementMethod": map[string]interface{}{
+				"type":                        d.kind,
+				"bankAccountNo":               d.accountNo,
+				"bankShortCode":               d.bankShortCode,
+				"bankName":                    d.bankName,
+				"bankAccountHolderName":       d.holderName,
+				"serverBankAccountHolderName": d.serverHolderName,
+			},
+		},
+	}
+}
+
+func (s *Server) findDisbursement(id string) *disbursement {
+	for _, d := range s.disbursements {
+		if d.id == id {
+			return d
+		}
+	}
+	return nil
+}
+
+func (s *Server) createDisbursement(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Amount             money  `json:"amount"`
+		ReferenceID        string `json:"referenceId"`
+		Description        string `json:"description"`
+		DisbursementMethod struct {
+			Type                  string `json:"type"`
+			BankShortCode         string `json:"bankShortCode"`
+			BankAccountNo         string `json:"bankAccountNo"`
+			BankAccountHolderName string `json:"bankAccountHolderName"`
+		} `json:"disbursementMethod"`
+	}
+
+	if !decode(w, r, &req) {
+		return
+	}
+
+	if req.ReferenceID == "" {
+		writeError(w, http.StatusUnprocessableEntity, "invalid_parameter", "Unprocessable Entity", "referenceId is required")
+		return
+	}
+
+	if req.Amount <= 0 {
+		writeError(w, http.StatusUnprocessableEntity, "invalid_parameter", "Unprocessable Entity", "amount must be greater than 0")
+		return
+	}
+
+	for _, d := range s.disbursements {
+		if d.refID == req.ReferenceID {
+			writeError(w, http.StatusUnprocessableEntity, "duplicate_reference_id", "Unprocessable Entity", "referenceId has already been taken")
+			return
+		}
+	}
+
+	var bankName string
+	for _, b := range s.banks {
+		if strings.EqualFold(b.ShortCode, req.DisbursementMethod.BankShortCode) {
+			bankName = b.Name
+		}
+	}
+
+	if bankName == "" {
+		writeError(w, http.StatusUnprocessableEntity, "invalid_bank", "Unprocessable Entity", "bank short code is not supported")
+		return
+	}
+
+	if s.available < req.Amount {
+		writeError(w, http.StatusUnprocessableEntity, "insufficient_balance", "Unprocessable Entity", "insufficient balance")
+		return
+	}
+
+	d := &disbursement{
+		id:               s.nextID("contract"),
+		refID:            req.ReferenceID,
+		amount:           req.Amount,
+		state:            "processing",
+		description:      req.Description,
+		created:          s.Now(),
+		kind:             req.DisbursementMethod.Type,
+		bankShortCode:    strings.ToUpper(req.DisbursementMethod.BankShortCode),
+		bankName:         bankName,
+		accountNo:        req.DisbursementMethod.BankAccountNo,
+		holderName:       req.DisbursementMethod.BankAccountHolderName,
+		serverHolderName: s.accounts[accountKey(req.DisbursementMethod.BankShortCode, req.DisbursementMethod.BankAccountNo)],
+	}
+
+	s.disbursements = append(s.disbursements, d)
+	s.available -= d.amount
+	s.pending += d.amount
+
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"data": d.resource()})
+}
+
+func (s *Server) getDisbursement(w http.ResponseWriter, id string) {
+	d := s.findDisbursement(id)
+	if d == nil {
+		writeError(w, http.StatusNotFound, "not_found", "Not Found", "disbursement not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": d.resource()})
+}
+
+func (s *Server) getDisbursements(w http.ResponseWriter, r *http.Request) {
+	writeList(w, r, s.disbursements)
+}
+
+func (s *Server) simulateDisbursement(w http.ResponseWriter, r *http.Request, id string) {
+	var req struct {
+		Action string `json:"action"`
+	}
+
+	if !decode(w, r, &req) {
+		return
+	}
+
+	d := s.findDisbursement(id)
+	if d == nil {
+		writeError(w, http.StatusNotFound, "not_found", "Not Found", "disbursement not found")
+		return
+	}
+
+	switch {
+	case req.Action == "complete" && d.state == "processing":
+		d.state = "completed"
+		s.pending -= d.amount
+	case req.Action == "fail" && d.state == "processing":
+		d.state = "failed"
+		d.failureReason = "simulated failure"
+		s.pending -= d.amount
+		s.available += d.amount
+	default:
+		writeError(w, http.StatusUnprocessableEntity, "invalid_action", "Unprocessable Entity", "action "+req.Action+" is not allowed for "+d.state+" disbursement")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"data": map[string]interface{}{
+			"id":   s.nextID("task"),
+			"type": "task",
+			"attributes": map[string]interface{}{
+				"targetId":   d.id,
+				"targetType": "disbursement",
+				"action":     req.Action,
+			},
+		},
+	})
+}
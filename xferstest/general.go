@@ -0,0 +1,97 @@
+package xferstest
+
+import (
+	"net/http"
+	"strings"
+)
+
+type bank struct {
+	Name      string
+	ShortCode string
+}
+
+func defaultBanks() []bank {
+	return []bank{
+		{Name: "Bank Central Asia", ShortCode: "BCA"},
+		{Name: "Bank Mandiri", ShortCode: "MANDIRI"},
+		{Name: "Bank Negara Indonesia", ShortCode: "BNI"},
+		{Name: "Bank Rakyat Indonesia", ShortCode: "BRI"},
+		{Name: "Bank Permata", ShortCode: "PERMATA"},
+		{Name: "Bank CIMB Niaga", ShortCode: "CIMB_NIAGA"},
+		{Name: "Bank Danamon", ShortCode: "DANAMON"},
+		{Name: "Bank KEB Hana Indonesia", ShortCode: "HANA"},
+		{Name: "Bank Sahabat Sampoerna", ShortCode: "SAHABAT_SAMPOERNA"},
+	}
+}
+
+func (s *Server) getBalance(w http.ResponseWriter) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"data": map[string]interface{}{
+			"id":   "balance_overview",
+			"type": "balance_overview",
+			"attributes": map[string]interface{}{
+				"totalBalance":     s.available + s.pending,
+				"availableBalance": s.available,
+				"pendingBalance":   s.pending,
+			},
+		},
+	})
+}
+
+func (s *Server) getBanks(w http.ResponseWriter) {
+	data := make([]interface{}, len(s.banks))
+	for i, b := range s.banks {
+		data[i] = map[string]interface{}{
+			"id":   b.ShortCode,
+			"type": "bank",
+			"attributes": map[string]interface{}{
+				"name":      b.Name,
+				"shortCode": b.ShortCode,
+			},
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": data})
+}
+
+func (s *Server) isBank(code string) bool {
+	for _, b := range s.banks {
+		if strings.EqualFold(b.ShortCode, code) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) validateBankAccount(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		AccountNo     string `json:"accountNo"`
+		BankShortCode string `json:"bankShortCode"`
+	}
+
+	if !decode(w, r, &req) {
+		return
+	}
+
+	if !s.isBank(req.BankShortCode) {
+		writeError(w, http.StatusUnprocessableEntity, "invalid_bank", "Unprocessable Entity", "bank short code is not supported")
+		return
+	}
+
+	name, ok := s.accounts[accountKey(req.BankShortCode, req.AccountNo)]
+	if !ok {
+		writeError(w, http.StatusUnprocessableEntity, "invalid_bank_account", "Unprocessable Entity", "bank account is invalid")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"data": map[string]interface{}{
+			"id":   s.nextID("bank_account_validation"),
+			"type": "bank_account_validation",
+			"attributes": map[string]interface{}{
+				"accountName":   name,
+				"accountNo":     req.AccountNo,
+				"bankShortCode": strings.ToUpper(req.BankShortCode),
+			},
+		},
+	})
+}
@@ -0,0 +1,115 @@
+package xferstest
+
+import (
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+)
+
+type listable interface {
+	referenceID() string
+	status() string
+	createdAt() time.Time
+	resource() map[string]interface{}
+}
+
+// writeList to filter, sort and paginate the items following
+// the query parameters used by the client's Pagination.
+func writeList[T listable](w http.ResponseWriter, r *http.Request, items []T) {
+	q := r.URL.Query()
+
+	page, size := 1, 10
+	if v, err := strconv.Atoi(q.Get("page[number]")); err == nil && v > 0 {
+		page = v
+	}
+	if v, err := strconv.Atoi(q.Get("page[size]")); err == nil && v > 0 {
+		size = v
+	}
+
+	var after, before time.Time
+	if v := q.Get("filter[createdAfter]"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_filter", "Bad Request", "invalid filter[createdAfter]")
+			return
+		}
+		after = t
+	}
+	if v := q.Get("filter[createdBefore]"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_filter", "Bad Request", "invalid filter[createdBefore]")
+			return
+		}
+		before = t
+	}
+
+	filtered := make([]T, 0, len(items))
+	for _, item := range items {
+		if v := q.Get("filter[status]"); v != "" && item.status() != v {
+			continue
+		}
+		if v := q.Get("filter[referenceId]"); v != "" && item.referenceID() != v {
+			continue
+		}
+		if !after.IsZero() && item.createdAt().Before(after) {
+			continue
+		}
+		if !before.IsZero() && item.createdAt().After(before) {
+			continue
+		}
+		filtered = append(filtered, item)
+	}
+
+	// Newest first by default.
+	asc := q.Get("sort") == "createdAt"
+	sort.SliceStable(filtered, func(i, j int) bool {
+		if asc {
+			return filtered[i].createdAt().Before(filtered[j].createdAt())
+		}
+		return filtered[i].createdAt().After(filtered[j].createdAt())
+	})
+
+	total := len(filtered)
+	start := min((page-1)*size, total)
+	end := min(start+size, total)
+
+	data := make([]interface{}, 0, end-start)
+	for _, item := range filtered[start:end] {
+		data = append(data, item.resource())
+	}
+
+	links := map[string]interface{}{
+		"self":  pageURL(r, page, size),
+		"first": pageURL(r, 1, size),
+	}
+	if page > 1 {
+		links["prev"] = pageURL(r, page-1, size)
+	}
+	if end < total {
+		links["next"] = pageURL(r, page+1, size)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"data": data,
+		"meta": map[string]interface{}{
+			"totalCount": total,
+			"pageNumber": page,
+			"pageSize":   size,
+		},
+		"links": links,
+	})
+}
+
+func pageURL(r *http.Request, page, size int) string {
+	q := url.Values{}
+	for k, v := range r.URL.Query() {
+		q[k] = v
+	}
+	q.Set("page[number]", strconv.Itoa(page))
+	q.Set("page[size]", strconv.Itoa(size))
+	u := url.URL{Path: r.URL.Path, RawQuery: q.Encode()}
+	return u.String()
+}
@@ -0,0 +1,39 @@
+package xferstest
+
+import (
+	"encoding/json"
+
+	"github.com/rl404/xfers-go"
+)
+
+// money is amount in minor unit (cent) to keep the balance
+// accounting exact.
+type money int64
+
+func parseMoney(str string) (money, error) {
+	a, err := xfers.ParseAmount(str)
+	if err != nil {
+		return 0, err
+	}
+	return money(a.Minor()), nil
+}
+
+func (m money) String() string {
+	return xfers.NewAmountFromMinor(int64(m)).String()
+}
+
+// UnmarshalJSON to accept amount as json number or string.
+func (m *money) UnmarshalJSON(b []byte) error {
+	var a xfers.Amount
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	*m = money(a.Minor())
+	return nil
+}
+
+// MarshalJSON to encode amount as string like the real API.
+func (m money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.String())
+}
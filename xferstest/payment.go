@@ -0,0 +1,226 @@
+package xferstest
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+type payment struct {
+	id              string
+	refID           string
+	amount          money
+	fees            money
+	state           string
+	description     string
+	created         time.Time
+	expiredAt       time.Time
+	methodID        string
+	methodType      string
+	methodRefID     string
+	displayName     string
+	retailOutlet    string
+	paymentCode     string
+	bankShortCode   string
+	accountNo       string
+	imageURL        string
+	httpURL         string
+	afterSettlement string
+}
+
+func (p *payment) referenceID() string  { return p.refID }
+func (p *payment) status() string       { return p.state }
+func (p *payment) createdAt() time.Time { return p.created }
+
+func (p *payment) resource() map[string]interface{} {
+	return map[string]interface{}{
+		"id":   p.id,
+		"type": "payment",
+		"attributes": map[string]interface{}{
+			"status":      p.state,
+			"amount":      p.amount,
+			"createdAt":   p.created,
+			"description": p.description,
+			"expiredAt":   p.expiredAt,
+			"referenceId": p.refID,
+			"fees":        p.fees,
+			"paymentMethod": map[string]interface{}{
+				"id":          p.methodID,
+				"type":        p.methodType,
+				"referenceId": p.methodRefID,
+				"instructions": map[string]interface{}{
+					"displayName":      p.displayName,
+					"retailOutletCode": p.retailOutlet,
+					"paymentCode":      p.paymentCode,
+					"bankShortCode":    p.bankShortCode,
+					"accountNo":        p.accountNo,
+					"imageUrl":         p.imageURL,
+				},
+				"settlement": map[string]interface{}{
+					"httpUrl":            p.httpURL,
+					"afterSettlementUrl": p.afterSettlement,
+				},
+			},
+		},
+	}
+}
+
+func (s *Server) findPayment(id string) *payment {
+	for _, p := range s.payments {
+		if p.id == id {
+			s.expirePayment(p)
+			return p
+		}
+	}
+	return nil
+}
+
+func (s *Server) expirePayment(p *payment) {
+	if p.state == "pending" && !p.expiredAt.IsZero() && s.Now().After(p.expiredAt) {
+		p.state = "expired"
+	}
+}
+
+func (s *Server) createPayment(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		PaymentMethodType    string    `json:"paymentMethodType"`
+		Amount               money     `json:"amount"`
+		ReferenceID          string    `json:"referenceId"`
+		ExpiredAt            time.Time `json:"expiredAt"`
+		Description          string    `json:"description"`
+		PaymentMethodOptions struct {
+			DisplayName              string `json:"displayName"`
+			RetailOutletName         string `json:"retailOutletName"`
+			BankShortCode            string `json:"bankShortCode"`
+			SuffixNo                 string `json:"suffixNo"`
+			ProviderCode             string `json:"providerCode"`
+			AfterSettlementReturnURL string `json:"afterSettlementReturnUrl"`
+		} `json:"paymentMethodOptions"`
+	}
+
+	if !decode(w, r, &req) {
+		return
+	}
+
+	if req.ReferenceID == "" {
+		writeError(w, http.StatusUnprocessableEntity, "invalid_parameter", "Unprocessable Entity", "referenceId is required")
+		return
+	}
+
+	if req.Amount <= 0 {
+		writeError(w, http.StatusUnprocessableEntity, "invalid_parameter", "Unprocessable Entity", "amount must be greater than 0")
+		return
+	}
+
+	for _, p := range s.payments {
+		if p.refID == req.ReferenceID {
+			writeError(w, http.StatusUnprocessableEntity, "duplicate_reference_id", "Unprocessable Entity", "referenceId has already been taken")
+			return
+		}
+	}
+
+	p := &payment{
+		id:          s.nextID("contract"),
+		refID:       req.ReferenceID,
+		amount:      req.Amount,
+		state:       "pending",
+		description: req.Description,
+		created:     s.Now(),
+		expiredAt:   req.ExpiredAt,
+		methodType:  req.PaymentMethodType,
+		methodRefID: req.ReferenceID,
+		displayName: req.PaymentMethodOptions.DisplayName,
+	}
+
+	switch req.PaymentMethodType {
+	case "virtual_bank_account":
+		p.methodID = s.nextID("va")
+		p.bankShortCode = strings.ToUpper(req.PaymentMethodOptions.BankShortCode)
+		p.accountNo = "8808" + strings.TrimPrefix(p.methodID, "va_") + req.PaymentMethodOptions.SuffixNo
+	case "retail_outlet":
+		p.methodID = s.nextID("ro")
+		p.retailOutlet = strings.ToUpper(req.PaymentMethodOptions.RetailOutletName)
+		p.paymentCode = "XFERS" + strings.TrimPrefix(p.methodID, "ro_")
+	case "qris":
+		p.methodID = s.nextID("qris")
+		p.imageURL = s.URL + "/qris/" + p.methodID + ".png"
+	case "e-wallet":
+		p.methodID = s.nextID("ewallet")
+		p.httpURL = s.URL + "/e-wallet/" + p.methodID
+		p.afterSettlement = req.PaymentMethodOptions.AfterSettlementReturnURL
+	default:
+		writeError(w, http.StatusUnprocessableEntity, "invalid_parameter", "Unprocessable Entity", "paymentMethodType is invalid")
+		return
+	}
+
+	s.payments = append(s.payments, p)
+
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"data": p.resource()})
+}
+
+func (s *Server) getPayment(w http.ResponseWriter, id string) {
+	p := s.findPayment(id)
+	if p == nil {
+		writeError(w, http.StatusNotFound, "not_found", "Not Found", "payment not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": p.resource()})
+}
+
+func (s *Server) getPayments(w http.ResponseWriter, r *http.Request, payments []*payment) {
+	for _, p := range payments {
+		s.expirePayment(p)
+	}
+	writeList(w, r, payments)
+}
+
+func (s *Server) simulatePayment(w http.ResponseWriter, r *http.Request, id string) {
+	var req struct {
+		Action  string `json:"action"`
+		Options struct {
+			Amount money `json:"amount"`
+		} `json:"options"`
+	}
+
+	if !decode(w, r, &req) {
+		return
+	}
+
+	p := s.findPayment(id)
+	if p == nil {
+		writeError(w, http.StatusNotFound, "not_found", "Not Found", "payment not found")
+		return
+	}
+
+	switch {
+	case req.Action == "receive_payment" && p.state == "pending":
+		if req.Options.Amount > 0 && req.Options.Amount != p.amount {
+			writeError(w, http.StatusUnprocessableEntity, "invalid_amount", "Unprocessable Entity", "amount does not match payment amount")
+			return
+		}
+		p.state = "paid"
+		s.pending += p.amount - p.fees
+	case req.Action == "settle" && p.state == "paid":
+		p.state = "completed"
+		s.pending -= p.amount - p.fees
+		s.available += p.amount - p.fees
+	case req.Action == "cancel" && p.state == "pending":
+		p.state = "cancelled"
+	default:
+		writeError(w, http.StatusUnprocessableEntity, "invalid_action", "Unprocessable Entity", "action "+req.Action+" is not allowed for "+p.state+" payment")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"data": map[string]interface{}{
+			"id":   s.nextID("task"),
+			"type": "task",
+			"attributes": map[string]interface{}{
+				"targetId":   p.id,
+				"targetType": "payment",
+				"action":     req.Action,
+			},
+		},
+	})
+}
@@ -0,0 +1,192 @@
+package xferstest
+
+import (
+	"net/http"
+	"strings"
+)
+
+type paymentMethod struct {
+	id            string
+	kind          string
+	refID         string
+	displayName   string
+	bankShortCode string
+	accountNo     string
+	imageURL      string
+}
+
+func (p *paymentMethod) resource() map[string]interface{} {
+	return map[string]interface{}{
+		"id":   p.id,
+		"type": p.kind,
+		"attributes": map[string]interface{}{
+			"referenceId": p.refID,
+			"instructions": map[string]interface{}{
+				"displayName":   p.displayName,
+				"bankShortCode": p.bankShortCode,
+				"accountNo":     p.accountNo,
+				"imageUrl":      p.imageURL,
+			},
+		},
+	}
+}
+
+// paymentMethodType to convert url path to payment method type.
+func paymentMethodType(path string) string {
+	switch path {
+	case "virtual_bank_accounts":
+		return "virtual_bank_account"
+	case "qris":
+		return "qris"
+	default:
+		return ""
+	}
+}
+
+func (s *Server) findPaymentMethod(kind, id string) *paymentMethod {
+	for _, p := range s.paymentMethods {
+		if p.id == id && p.kind == paymentMethodType(kind) {
+			return p
+		}
+	}
+	return nil
+}
+
+func (s *Server) createPaymentMethod(w http.ResponseWriter, r *http.Request, kind string) {
+	var req struct {
+		ReferenceID   string `json:"referenceId"`
+		DisplayName   string `json:"displayName"`
+		BankShortCode string `json:"bankShortCode"`
+		SuffixNo      string `json:"suffixNo"`
+	}
+
+	if !decode(w, r, &req) {
+		return
+	}
+
+	t := paymentMethodType(kind)
+	if t == "" {
+		writeError(w, http.StatusNotFound, "not_found", "Not Found", "payment method type not found")
+		return
+	}
+
+	if req.ReferenceID == "" {
+		writeError(w, http.StatusUnprocessableEntity, "invalid_parameter", "Unprocessable Entity", "referenceId is required")
+		return
+	}
+
+	for _, p := range s.paymentMethods {
+		if p.refID == req.ReferenceID {
+			writeError(w, http.StatusUnprocessableEntity, "duplicate_reference_id", "Unprocessable Entity", "referenceId has already been taken")
+			return
+		}
+	}
+
+	p := &paymentMethod{
+		kind:        t,
+		refID:       req.ReferenceID,
+		displayName: req.DisplayName,
+	}
+
+	switch t {
+	case "virtual_bank_account":
+		p.id = s.nextID("va")
+		p.bankShortCode = strings.ToUpper(req.BankShortCode)
+		p.accountNo = "8808" + strings.TrimPrefix(p.id, "va_") + req.SuffixNo
+	case "qris":
+		p.id = s.nextID("qris")
+		p.imageURL = s.URL + "/qris/" + p.id + ".png"
+	}
+
+	s.paymentMethods = append(s.paymentMethods, p)
+
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"data": p.resource()})
+}
+
+func (s *Server) getPaymentMethod(w http.ResponseWriter, kind, id string) {
+	p := s.findPaymentMethod(kind, id)
+	if p == nil {
+		writeError(w, http.StatusNotFound, "not_found", "Not Found", "payment method not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": p.resource()})
+}
+
+func (s *Server) getPaymentMethodPayments(w http.ResponseWriter, r *http.Request, kind, id string) {
+	if s.findPaymentMethod(kind, id) == nil {
+		writeError(w, http.StatusNotFound, "not_found", "Not Found", "payment method not found")
+		return
+	}
+
+	var payments []*payment
+	for _, p := range s.payments {
+		if p.methodID == id {
+			payments = append(payments, p)
+		}
+	}
+
+	s.getPayments(w, r, payments)
+}
+
+func (s *Server) simulatePaymentMethod(w http.ResponseWriter, r *http.Request, kind, id string) {
+	var req struct {
+		Action  string `json:"action"`
+		Options struct {
+			Amount money `json:"amount"`
+		} `json:"options"`
+	}
+
+	if !decode(w, r, &req) {
+		return
+	}
+
+	pm := s.findPaymentMethod(kind, id)
+	if pm == nil {
+		writeError(w, http.StatusNotFound, "not_found", "Not Found", "payment method not found")
+		return
+	}
+
+	if req.Action != "receive_payment" {
+		writeError(w, http.StatusUnprocessableEntity, "invalid_action", "Unprocessable Entity", "action "+req.Action+" is not allowed for payment method")
+		return
+	}
+
+	if req.Options.Amount <= 0 {
+		writeError(w, http.StatusUnprocessableEntity, "invalid_amount", "Unprocessable Entity", "amount must be greater than 0")
+		return
+	}
+
+	p := &payment{
+		id:            s.nextID("contract"),
+		refID:         s.nextID("payment"),
+		amount:        req.Options.Amount,
+		state:         "completed",
+		created:       s.Now(),
+		methodID:      pm.id,
+		methodType:    pm.kind,
+		methodRefID:   pm.refID,
+		displayName:   pm.displayName,
+		bankShortCode: pm.bankShortCode,
+		accountNo:     pm.accountNo,
+		imageURL:      pm.imageURL,
+	}
+
+	s.payments = append(s.payments, p)
+	s.available += p.amount
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"data": map[string]interface{}{
+			"id":   s.nextID("task"),
+			"type": "task",
+			"attributes": map[string]interface{}{
+				"targetId":   pm.id,
+				"targetType": pm.kind,
+				"action":     req.Action,
+				"options": map[string]interface{}{
+					"amount": req.Options.Amount,
+				},
+			},
+		},
+	})
+}
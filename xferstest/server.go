@@ -0,0 +1,196 @@
+// Package xferstest provides an in-memory fake xfers V4 server
+// for offline integration tests.
+//
+//	srv := xferstest.NewServer("api-key", "secret-key")
+//	defer srv.Close()
+//
+//	client := xfers.New(xfers.Option{
+//		APIKey:    "api-key",
+//		SecretKey: "secret-key",
+//		BaseURL:   srv.URL,
+//	})
+package xferstest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Server is in-memory fake xfers server.
+//
+// All state is kept in memory and guarded by a mutex so
+// the server can be used by concurrent clients.
+type Server struct {
+	*httptest.Server
+
+	// Now is used as resource creation time.
+	// Default is time.Now.
+	Now func() time.Time
+
+	apiKey    string
+	secretKey string
+
+	mu             sync.Mutex
+	seq            int
+	available      money
+	pending        money
+	banks          []bank
+	accounts       map[string]string
+	payments       []*payment
+	paymentMethods []*paymentMethod
+	disbursements  []*disbursement
+}
+
+// NewServer to create and start new fake xfers server.
+func NewServer(apiKey, secretKey string) *Server {
+	s := &Server{
+		Now:       time.Now,
+		apiKey:    apiKey,
+		secretKey: secretKey,
+		banks:     defaultBanks(),
+		accounts:  make(map[string]string),
+	}
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+// SetBalance to set available balance. Pending balance is reset.
+func (s *Server) SetBalance(amount string) error {
+	m, err := parseMoney(amount)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.available = m
+	s.pending = 0
+	return nil
+}
+
+// AddBankAccount to register bank account so it can be validated
+// and disbursed to.
+func (s *Server) AddBankAccount(bankCode, accountNo, holderName string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.accounts[accountKey(bankCode, accountNo)] = holderName
+}
+
+// ServeHTTP to handle xfers API request.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if apiKey, secretKey, ok := r.BasicAuth(); !ok || apiKey != s.apiKey || secretKey != s.secretKey {
+		writeError(w, http.StatusUnauthorized, "unauthorized", "Unauthorized", "invalid api key or secret key")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	switch {
+	case match(r, http.MethodGet, path, "overviews", "balance_overview"):
+		s.getBalance(w)
+	case match(r, http.MethodGet, path, "banks"):
+		s.getBanks(w)
+	case match(r, http.MethodPost, path, "validation_services", "bank_account_validation"):
+		s.validateBankAccount(w, r)
+	case match(r, http.MethodPost, path, "payments"):
+		s.createPayment(w, r)
+	case match(r, http.MethodGet, path, "payments"):
+		s.getPayments(w, r, s.payments)
+	case match(r, http.MethodGet, path, "payments", "*"):
+		s.getPayment(w, path[1])
+	case match(r, http.MethodPost, path, "payments", "*", "tasks"):
+		s.simulatePayment(w, r, path[1])
+	case match(r, http.MethodPost, path, "payment_methods", "*"):
+		s.createPaymentMethod(w, r, path[1])
+	case match(r, http.MethodGet, path, "payment_methods", "*", "*"):
+		s.getPaymentMethod(w, path[1], path[2])
+	case match(r, http.MethodGet, path, "payment_methods", "*", "*", "payments"):
+		s.getPaymentMethodPayments(w, r, path[1], path[2])
+	case match(r, http.MethodPost, path, "payment_methods", "*", "*", "tasks"):
+		s.simulatePaymentMethod(w, r, path[1], path[2])
+	case match(r, http.MethodPost, path, "disbursements"):
+		s.createDisbursement(w, r)
+	case match(r, http.MethodGet, path, "disbursements"):
+		s.getDisbursements(w, r)
+	case match(r, http.MethodGet, path, "disbursements", "*"):
+		s.getDisbursement(w, path[1])
+	case match(r, http.MethodPost, path, "disbursements", "*", "tasks"):
+		s.simulateDisbursement(w, r, path[1])
+	default:
+		writeError(w, http.StatusNotFound, "not_found", "Not Found", "route not found")
+	}
+}
+
+func match(r *http.Request, method string, path []string, pattern ...string) bool {
+	if r.Method != method || len(path) != len(pattern) {
+		return false
+	}
+	for i, p := range pattern {
+		if p != "*" && p != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+type errorObject struct {
+	Code   string `json:"code"`
+	Title  string `json:"title"`
+	Detail string `json:"detail"`
+}
+
+func writeError(w http.ResponseWriter, status int, code, title, detail string) {
+	writeJSON(w, status, map[string]interface{}{
+		"errors": []errorObject{{Code: code, Title: title, Detail: detail}},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/vnd.api+json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+func decode(w http.ResponseWriter, r *http.Request, attributes interface{}) bool {
+	var body struct {
+		Data struct {
+			Attributes json.RawMessage `json:"attributes"`
+		} `json:"data"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_body", "Bad Request", err.Error())
+		return false
+	}
+
+	if len(body.Data.Attributes) == 0 {
+		writeError(w, http.StatusBadRequest, "invalid_body", "Bad Request", "missing data attributes")
+		return false
+	}
+
+	if err := json.Unmarshal(body.Data.Attributes, attributes); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_body", "Bad Request", err.Error())
+		return false
+	}
+
+	return true
+}
+
+func (s *Server) nextID(prefix string) string {
+	s.seq++
+	return fmt.Sprintf("%s_%06d", prefix, s.seq)
+}
+
+func accountKey(bankCode, accountNo string) string {
+	return strings.ToUpper(bankCode) + ":" + accountNo
+}
@@ -0,0 +1,193 @@
+package xferstest_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rl404/xfers-go"
+	"github.com/rl404/xfers-go/xferstest"
+)
+
+func newClient(t *testing.T, balance string) (*xfers.Client, *xferstest.Server) {
+	t.Helper()
+
+	srv := xferstest.NewServer("api-key", "secret-key")
+	t.Cleanup(srv.Close)
+
+	if err := srv.SetBalance(balance); err != nil {
+		t.Fatal(err)
+	}
+
+	return xfers.New(xfers.Option{
+		APIKey:    "api-key",
+		SecretKey: "secret-key",
+		BaseURL:   srv.URL,
+		Env:       xfers.Sandbox,
+	}), srv
+}
+
+func checkBalance(t *testing.T, client *xfers.Client, available, pending string) {
+	t.Helper()
+
+	balance, _, err := client.GetBalance()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if balance.AvailableBalance.String() != available || balance.PendingBalance.String() != pending {
+		t.Fatalf("got available %s pending %s, want %s %s", balance.AvailableBalance, balance.PendingBalance, available, pending)
+	}
+}
+
+func TestSetBalance(t *testing.T) {
+	tests := []struct {
+		amount  string
+		want    string
+		wantErr bool
+	}{
+		{amount: "10000", want: "10000.00"},
+		{amount: "10000.5", want: "10000.50"},
+		{amount: " 10000.50 ", want: "10000.50"},
+		{amount: "1.-5", wantErr: true},
+		{amount: "1.005", wantErr: true},
+		{amount: "abc", wantErr: true},
+		{amount: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.amount, func(t *testing.T) {
+			srv := xferstest.NewServer("api-key", "secret-key")
+			defer srv.Close()
+
+			err := srv.SetBalance(tt.amount)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if !errors.Is(err, xfers.ErrInvalidAmount) {
+					t.Fatalf("got %v, want %v", err, xfers.ErrInvalidAmount)
+				}
+				return
+			}
+
+			client := xfers.New(xfers.Option{APIKey: "api-key", SecretKey: "secret-key", BaseURL: srv.URL})
+			checkBalance(t, client, tt.want, "0.00")
+		})
+	}
+}
+
+func TestUnauthorized(t *testing.T) {
+	_, srv := newClient(t, "0")
+
+	client := xfers.New(xfers.Option{APIKey: "api-key", SecretKey: "wrong", BaseURL: srv.URL})
+	if _, _, err := client.GetBalance(); !xfers.IsUnauthorized(err) {
+		t.Fatalf("got %v, want unauthorized", err)
+	}
+}
+
+func TestDisbursementBalance(t *testing.T) {
+	tests := []struct {
+		action        xfers.Action
+		wantStatus    xfers.Status
+		wantAvailable string
+	}{
+		{action: xfers.ActionComplete, wantStatus: xfers.StatusCompleted, wantAvailable: "89999.50"},
+		{action: xfers.ActionFail, wantStatus: xfers.StatusFailed, wantAvailable: "100000.00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.action), func(t *testing.T) {
+			client, _ := newClient(t, "100000")
+
+			d, _, err := client.CreateDisbursement(xfers.CreateDisbursementRequest{
+				ReferenceID:           "d1",
+				Type:                  xfers.DisbursementBankTransfer,
+				BankAccountHolderName: "Budi",
+				BankAccountNo:         "1234567890",
+				BankShortCode:         xfers.BankBCA,
+				Amount:                xfers.NewAmountFromMinor(1000050),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if d.Amount.String() != "10000.50" || d.Status != xfers.StatusProcessing {
+				t.Fatalf("got %s %s", d.Amount, d.Status)
+			}
+			checkBalance(t, client, "89999.50", "10000.50")
+
+			if _, _, err := client.SimulateDisbursement(xfers.SimulateDisbursementRequest{ID: d.ID, Action: tt.action}); err != nil {
+				t.Fatal(err)
+			}
+
+			d, _, err = client.GetDisbursement(d.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if d.Status != tt.wantStatus {
+				t.Fatalf("got %s, want %s", d.Status, tt.wantStatus)
+			}
+			checkBalance(t, client, tt.wantAvailable, "0.00")
+		})
+	}
+}
+
+func TestDisbursementRejected(t *testing.T) {
+	client, _ := newClient(t, "10000")
+
+	request := xfers.CreateDisbursementRequest{
+		ReferenceID:           "d1",
+		Type:                  xfers.DisbursementBankTransfer,
+		BankAccountHolderName: "Budi",
+		BankAccountNo:         "1234567890",
+		BankShortCode:         xfers.BankBCA,
+		Amount:                xfers.NewAmount(20000),
+	}
+	if _, _, err := client.CreateDisbursement(request); !xfers.IsInsufficientBalance(err) {
+		t.Fatalf("got %v, want insufficient balance", err)
+	}
+
+	request.Amount = xfers.NewAmount(5000)
+	if _, _, err := client.CreateDisbursement(request); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := client.CreateDisbursement(request); !xfers.IsDuplicateReference(err) {
+		t.Fatalf("got %v, want duplicate reference", err)
+	}
+	if _, _, err := client.GetDisbursement("unknown"); !xfers.IsNotFound(err) {
+		t.Fatalf("got %v, want not found", err)
+	}
+}
+
+func TestPaymentSettle(t *testing.T) {
+	client, _ := newClient(t, "0")
+
+	p, _, err := client.CreatePayment(xfers.CreatePaymentRequest{
+		PaymentMethodType: xfers.PaymentQRIS,
+		Amount:            xfers.NewAmount(10000),
+		ReferenceID:       "p1",
+		ExpiredAt:         time.Now().Add(time.Hour),
+		DisplayName:       "Budi",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Status != xfers.StatusPending {
+		t.Fatalf("got %s, want %s", p.Status, xfers.StatusPending)
+	}
+
+	for _, action := range []xfers.Action{xfers.ActionReceivePayment, xfers.ActionSettle} {
+		if _, _, err := client.SimulatePayment(xfers.SimulatePaymentRequest{ID: p.ID, Action: action, Amount: p.Amount}); err != nil {
+			t.Fatalf("%s: %v", action, err)
+		}
+	}
+
+	p, _, err = client.GetPayment(p.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Status != xfers.StatusCompleted {
+		t.Fatalf("got %s, want %s", p.Status, xfers.StatusCompleted)
+	}
+	checkBalance(t, client, p.Amount.Sub(p.Fees).String(), "0.00")
+}